@@ -24,6 +24,18 @@ func TestReadme(t *testing.T) {
 		{
 			subCommand: "postgresql",
 		},
+		{
+			subCommand: "rails",
+		},
+		{
+			subCommand: "gostruct",
+		},
+		{
+			subCommand: "prisma",
+		},
+		{
+			subCommand: "migrations",
+		},
 	}
 
 	readme := readFile("../README.md")
@@ -7,3 +7,13 @@ type Adapter interface {
 	GetAllTableNames() ([]string, error)
 	GetTable(tableName string) (*db.Table, error)
 }
+
+// StatsAdapter represents database adapter which can load table statistics
+type StatsAdapter interface {
+	GetTableStats(tableName string) (*db.TableStats, error)
+}
+
+// SampleAdapter represents database adapter which can sample distinct values of column
+type SampleAdapter interface {
+	GetColumnValues(tableName string, columnName string, limit int) ([]string, error)
+}
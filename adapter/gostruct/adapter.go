@@ -0,0 +1,70 @@
+package gostruct
+
+import (
+	"fmt"
+	"github.com/cockroachdb/errors"
+	"github.com/sue445/plant_erd/db"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Adapter represents GORM model struct adapter
+type Adapter struct {
+	tables map[string]*db.Table
+}
+
+// NewAdapter returns a new Adapter instance
+func NewAdapter(dir string) (*Adapter, error) {
+	entries, err := os.ReadDir(dir)
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		files = append(files, file)
+	}
+
+	return &Adapter{tables: parseFiles(files)}, nil
+}
+
+// GetAllTableNames returns all table names of models in package
+func (a *Adapter) GetAllTableNames() ([]string, error) {
+	var tables []string
+	for tableName := range a.tables {
+		tables = append(tables, tableName)
+	}
+
+	sort.Strings(tables)
+
+	return tables, nil
+}
+
+// GetTable returns table info
+func (a *Adapter) GetTable(tableName string) (*db.Table, error) {
+	table, ok := a.tables[tableName]
+
+	if !ok {
+		return nil, fmt.Errorf("%s is not found in models", tableName)
+	}
+
+	return table, nil
+}
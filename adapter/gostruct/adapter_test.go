@@ -0,0 +1,235 @@
+package gostruct
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sue445/plant_erd/db"
+)
+
+const modelsGo = "package models\n" + `
+import (
+	"database/sql"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Status string
+
+type Timestamps struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type User struct {
+	gorm.Model
+	Name      string ` + "`gorm:\"size:100;not null\"`" + `
+	Email     string ` + "`gorm:\"uniqueIndex\"`" + `
+	Nickname  sql.NullString
+	Profile   Profile
+	Articles  []Article
+	Languages []*Language ` + "`gorm:\"many2many:user_languages\"`" + `
+	password  string
+	Ignored   string ` + "`gorm:\"-\"`" + `
+}
+
+type Profile struct {
+	ID     uint
+	UserID uint ` + "`gorm:\"not null\"`" + `
+	Bio    *string
+}
+
+type Article struct {
+	ArticleID uint   ` + "`gorm:\"primaryKey\"`" + `
+	AuthorID  uint   ` + "`gorm:\"index\"`" + `
+	Author    User   ` + "`gorm:\"foreignKey:AuthorID\"`" + `
+	Title     string ` + "`gorm:\"index:idx_articles_status_title,priority:2\"`" + `
+	Status    Status ` + "`gorm:\"default:'draft';index:idx_articles_status_title,priority:1,sort:desc\"`" + `
+	Price     float64 ` + "`gorm:\"precision:12;scale:2\"`" + `
+	Timestamps
+}
+
+type Language struct {
+	ID   uint
+	Name string ` + "`gorm:\"type:varchar(32);unique\"`" + `
+}
+
+func (Language) TableName() string {
+	return "langs"
+}
+
+type Config struct {
+	Debug bool
+}
+`
+
+func newTestAdapter(t *testing.T) *Adapter {
+	dir := t.TempDir()
+
+	err := os.WriteFile(filepath.Join(dir, "models.go"), []byte(modelsGo), 0644)
+	require.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(dir, "models_test.go"), []byte("package models\n\ntype Fixture struct {\n\tID uint `gorm:\"primaryKey\"`\n}\n"), 0644)
+	require.NoError(t, err)
+
+	adapter, err := NewAdapter(dir)
+	require.NoError(t, err)
+
+	return adapter
+}
+
+func TestAdapter_GetAllTableNames(t *testing.T) {
+	a := newTestAdapter(t)
+
+	tables, err := a.GetAllTableNames()
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"articles", "langs", "profiles", "user_languages", "users"}, tables)
+	}
+}
+
+func TestAdapter_GetTable(t *testing.T) {
+	a := newTestAdapter(t)
+
+	type args struct {
+		tableName string
+	}
+	tests := []struct {
+		name string
+		args args
+		want *db.Table
+	}{
+		{
+			name: "users",
+			args: args{
+				tableName: "users",
+			},
+			want: &db.Table{
+				Name: "users",
+				Columns: []*db.Column{
+					{Name: "id", Type: "uint", NotNull: true, PrimaryKey: true},
+					{Name: "created_at", Type: "time"},
+					{Name: "updated_at", Type: "time"},
+					{Name: "deleted_at", Type: "time"},
+					{Name: "name", Type: "string(100)", NotNull: true},
+					{Name: "email", Type: "string"},
+					{Name: "nickname", Type: "string"},
+				},
+				Indexes: []*db.Index{
+					{Name: "idx_users_deleted_at", Columns: []string{"deleted_at"}},
+					{Name: "idx_users_email", Columns: []string{"email"}, Unique: true},
+				},
+			},
+		},
+		{
+			name: "profiles",
+			args: args{
+				tableName: "profiles",
+			},
+			want: &db.Table{
+				Name: "profiles",
+				Columns: []*db.Column{
+					{Name: "id", Type: "uint", NotNull: true, PrimaryKey: true},
+					{Name: "user_id", Type: "uint", NotNull: true},
+					{Name: "bio", Type: "string"},
+				},
+				ForeignKeys: []*db.ForeignKey{
+					{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+				},
+			},
+		},
+		{
+			name: "articles",
+			args: args{
+				tableName: "articles",
+			},
+			want: &db.Table{
+				Name: "articles",
+				Columns: []*db.Column{
+					{Name: "article_id", Type: "uint", NotNull: true, PrimaryKey: true},
+					{Name: "author_id", Type: "uint"},
+					{Name: "title", Type: "string"},
+					{Name: "status", Type: "string", Default: "'draft'"},
+					{Name: "price", Type: "float(12,2)"},
+					{Name: "created_at", Type: "time"},
+					{Name: "updated_at", Type: "time"},
+				},
+				ForeignKeys: []*db.ForeignKey{
+					{FromColumn: "author_id", ToTable: "users", ToColumn: "id"},
+				},
+				Indexes: []*db.Index{
+					{Name: "idx_articles_author_id", Columns: []string{"author_id"}},
+					{
+						Name:    "idx_articles_status_title",
+						Columns: []string{"status", "title"},
+						Orders:  []string{"DESC"},
+					},
+				},
+			},
+		},
+		{
+			name: "langs",
+			args: args{
+				tableName: "langs",
+			},
+			want: &db.Table{
+				Name: "langs",
+				Columns: []*db.Column{
+					{Name: "id", Type: "uint", NotNull: true, PrimaryKey: true},
+					{Name: "name", Type: "varchar(32)"},
+				},
+				Indexes: []*db.Index{
+					{Name: "uni_langs_name", Columns: []string{"name"}, Unique: true},
+				},
+			},
+		},
+		{
+			name: "user_languages",
+			args: args{
+				tableName: "user_languages",
+			},
+			want: &db.Table{
+				Name: "user_languages",
+				Columns: []*db.Column{
+					{Name: "user_id", Type: "uint", NotNull: true, PrimaryKey: true},
+					{Name: "language_id", Type: "uint", NotNull: true, PrimaryKey: true},
+				},
+				ForeignKeys: []*db.ForeignKey{
+					{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+					{FromColumn: "language_id", ToTable: "langs", ToColumn: "id"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := a.GetTable(tt.args.tableName)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_toDBName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "ID", want: "id"},
+		{name: "UserID", want: "user_id"},
+		{name: "HTTPServer", want: "http_server"},
+		{name: "CreatedAt", want: "created_at"},
+		{name: "Field1", want: "field1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, toDBName(tt.name))
+		})
+	}
+}
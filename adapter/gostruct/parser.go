@@ -0,0 +1,822 @@
+package gostruct
+
+import (
+	"fmt"
+	"github.com/sue445/plant_erd/db"
+	"go/ast"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// defaultIndexPriority is same as GORM
+const defaultIndexPriority = 10
+
+// basicTypes represents GORM data types of Go basic types
+var basicTypes = map[string]string{
+	"bool":    "bool",
+	"int":     "int",
+	"int8":    "int",
+	"int16":   "int",
+	"int32":   "int",
+	"int64":   "int",
+	"rune":    "int",
+	"uint":    "uint",
+	"uint8":   "uint",
+	"uint16":  "uint",
+	"uint32":  "uint",
+	"uint64":  "uint",
+	"uintptr": "uint",
+	"byte":    "uint",
+	"float32": "float",
+	"float64": "float",
+	"string":  "string",
+}
+
+// nullableTypes represents GORM data types of database/sql Null* types
+var nullableTypes = map[string]string{
+	"NullBool":    "bool",
+	"NullByte":    "uint",
+	"NullFloat64": "float",
+	"NullInt16":   "int",
+	"NullInt32":   "int",
+	"NullInt64":   "int",
+	"NullString":  "string",
+	"NullTime":    "time",
+}
+
+type tagSetting struct {
+	key   string
+	value string
+}
+
+type tagSettings []tagSetting
+
+// get returns value of first setting which matches one of keys
+func (s tagSettings) get(keys ...string) (string, bool) {
+	for _, setting := range s {
+		for _, key := range keys {
+			if setting.key == key {
+				return setting.value, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (s tagSettings) has(keys ...string) bool {
+	_, ok := s.get(keys...)
+	return ok
+}
+
+type association struct {
+	fieldName  string
+	structName string
+	isSlice    bool
+	settings   tagSettings
+}
+
+type indexColumn struct {
+	column string
+
+	// expression represents expression key part. column is empty when this is set
+	expression string
+
+	priority int
+	order    string
+	length   int
+}
+
+type indexDefinition struct {
+	index   *db.Index
+	columns []*indexColumn
+}
+
+type model struct {
+	structName    string
+	table         *db.Table
+	fieldColumns  map[string]string
+	primaryFields []string
+	associations  []*association
+	indexes       []*indexDefinition
+}
+
+type packageParser struct {
+	structs    map[string]*ast.StructType
+	typeSpecs  map[string]ast.Expr
+	tableNames map[string]string
+	models     map[string]*model
+}
+
+// parseFiles parses GORM model structs in files and returns tables
+func parseFiles(files []*ast.File) map[string]*db.Table {
+	p := &packageParser{
+		structs:    map[string]*ast.StructType{},
+		typeSpecs:  map[string]ast.Expr{},
+		tableNames: map[string]string{},
+		models:     map[string]*model{},
+	}
+
+	for _, file := range files {
+		p.collectDecls(file)
+	}
+
+	var structNames []string
+	for _, structName := range p.detectModels() {
+		p.models[structName] = p.buildModel(structName)
+		structNames = append(structNames, structName)
+	}
+
+	tables := map[string]*db.Table{}
+	for _, structName := range structNames {
+		tables[p.models[structName].table.Name] = p.models[structName].table
+	}
+
+	for _, structName := range structNames {
+		for _, assoc := range p.models[structName].associations {
+			p.applyAssociation(p.models[structName], assoc, tables)
+		}
+	}
+
+	return tables
+}
+
+func (p *packageParser) collectDecls(file *ast.File) {
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+
+				p.typeSpecs[typeSpec.Name.Name] = typeSpec.Type
+				if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+					p.structs[typeSpec.Name.Name] = structType
+				}
+			}
+
+		case *ast.FuncDecl:
+			// e.g. func (User) TableName() string { return "members" }
+			if d.Recv == nil || len(d.Recv.List) == 0 || d.Name.Name != "TableName" || d.Body == nil || len(d.Body.List) != 1 {
+				continue
+			}
+
+			ret, ok := d.Body.List[0].(*ast.ReturnStmt)
+			if !ok || len(ret.Results) != 1 {
+				continue
+			}
+
+			lit, ok := ret.Results[0].(*ast.BasicLit)
+			if !ok {
+				continue
+			}
+
+			tableName, err := strconv.Unquote(lit.Value)
+			if err != nil {
+				continue
+			}
+
+			recvType, _ := unwrapType(d.Recv.List[0].Type)
+			if ident, ok := recvType.(*ast.Ident); ok {
+				p.tableNames[ident.Name] = tableName
+			}
+		}
+	}
+}
+
+// detectModels returns struct names which are mapped to table
+func (p *packageParser) detectModels() []string {
+	// Structs which are only embedded into other structs aren't model
+	embedded := map[string]bool{}
+	for _, structType := range p.structs {
+		for _, field := range structType.Fields.List {
+			if len(field.Names) > 0 && !parseTag(field).has("EMBEDDED") {
+				continue
+			}
+
+			fieldType, _ := unwrapType(field.Type)
+			if ident, ok := fieldType.(*ast.Ident); ok {
+				embedded[ident.Name] = true
+			}
+		}
+	}
+
+	models := map[string]bool{}
+	for structName, structType := range p.structs {
+		if !ast.IsExported(structName) {
+			continue
+		}
+
+		if _, ok := p.tableNames[structName]; ok {
+			models[structName] = true
+			continue
+		}
+
+		if !embedded[structName] && isGormModelStruct(structType) {
+			models[structName] = true
+		}
+	}
+
+	// Structs which are referenced from model as association are model too
+	for changed := true; changed; {
+		changed = false
+		for structName := range models {
+			for _, field := range p.structs[structName].Fields.List {
+				if len(field.Names) == 0 {
+					continue
+				}
+
+				settings := parseTag(field)
+				if settings.has("EMBEDDED", "TYPE", "SERIALIZER") {
+					continue
+				}
+
+				fieldType, _ := unwrapType(field.Type)
+				ident, ok := fieldType.(*ast.Ident)
+				if !ok || models[ident.Name] || embedded[ident.Name] || !ast.IsExported(ident.Name) {
+					continue
+				}
+
+				if _, ok := p.structs[ident.Name]; ok {
+					models[ident.Name] = true
+					changed = true
+				}
+			}
+		}
+	}
+
+	var structNames []string
+	for structName := range models {
+		structNames = append(structNames, structName)
+	}
+	sort.Strings(structNames)
+
+	return structNames
+}
+
+func isGormModelStruct(structType *ast.StructType) bool {
+	for _, field := range structType.Fields.List {
+		if len(field.Names) == 0 && isGormModel(field.Type) {
+			return true
+		}
+
+		if field.Tag != nil && reflect.StructTag(unquoteTag(field.Tag.Value)).Get("gorm") != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *packageParser) buildModel(structName string) *model {
+	tableName, ok := p.tableNames[structName]
+	if !ok {
+		tableName = db.Pluralize(toDBName(structName))
+	}
+
+	m := &model{
+		structName:   structName,
+		table:        &db.Table{Name: tableName},
+		fieldColumns: map[string]string{},
+	}
+
+	p.addFields(m, p.structs[structName], "", map[string]bool{structName: true})
+
+	if len(m.primaryFields) == 0 {
+		// GORM uses field named ID as primary key by default
+		for _, column := range m.table.Columns {
+			if m.fieldColumns["ID"] == column.Name {
+				column.PrimaryKey = true
+				column.NotNull = true
+				m.primaryFields = append(m.primaryFields, "ID")
+			}
+		}
+	}
+
+	for _, definition := range m.indexes {
+		sort.SliceStable(definition.columns, func(i, j int) bool {
+			return definition.columns[i].priority < definition.columns[j].priority
+		})
+
+		for _, indexColumn := range definition.columns {
+			if indexColumn.expression != "" {
+				definition.index.AddExpression(indexColumn.expression)
+				continue
+			}
+
+			definition.index.AddColumn(indexColumn.column)
+
+			if indexColumn.order != "" {
+				definition.index.SetOrder(indexColumn.order)
+			}
+
+			if indexColumn.length > 0 {
+				definition.index.SetLength(indexColumn.length)
+			}
+		}
+
+		m.table.Indexes = append(m.table.Indexes, definition.index)
+	}
+
+	return m
+}
+
+// addFields adds columns of struct fields to model. visited prevents infinite recursion of embedded structs
+func (p *packageParser) addFields(m *model, structType *ast.StructType, prefix string, visited map[string]bool) {
+	for _, field := range structType.Fields.List {
+		settings := parseTag(field)
+
+		if settings.has("-") {
+			continue
+		}
+
+		if len(field.Names) == 0 {
+			if isGormModel(field.Type) {
+				p.addGormModelFields(m)
+				continue
+			}
+
+			p.addEmbeddedFields(m, field.Type, prefix, settings, visited)
+			continue
+		}
+
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+
+			if settings.has("EMBEDDED") {
+				p.addEmbeddedFields(m, field.Type, prefix, settings, visited)
+				continue
+			}
+
+			fieldType, isSlice := unwrapType(field.Type)
+			if ident, ok := fieldType.(*ast.Ident); ok && !settings.has("TYPE", "SERIALIZER") {
+				if _, ok := p.structs[ident.Name]; ok {
+					m.associations = append(m.associations, &association{
+						fieldName:  name.Name,
+						structName: ident.Name,
+						isSlice:    isSlice,
+						settings:   settings,
+					})
+					continue
+				}
+			}
+
+			column := p.buildColumn(name.Name, field.Type, prefix, settings)
+			if column == nil {
+				continue
+			}
+
+			m.table.Columns = append(m.table.Columns, column)
+			m.fieldColumns[name.Name] = column.Name
+
+			if column.PrimaryKey {
+				m.primaryFields = append(m.primaryFields, name.Name)
+			}
+
+			m.addIndexes(column.Name, settings)
+		}
+	}
+}
+
+func (p *packageParser) addEmbeddedFields(m *model, fieldType ast.Expr, prefix string, settings tagSettings, visited map[string]bool) {
+	fieldType, _ = unwrapType(fieldType)
+
+	ident, ok := fieldType.(*ast.Ident)
+	if !ok || visited[ident.Name] {
+		return
+	}
+
+	structType, ok := p.structs[ident.Name]
+	if !ok {
+		return
+	}
+
+	embeddedPrefix, _ := settings.get("EMBEDDEDPREFIX")
+
+	visited[ident.Name] = true
+	p.addFields(m, structType, prefix+embeddedPrefix, visited)
+	delete(visited, ident.Name)
+}
+
+// addGormModelFields adds columns of gorm.Model
+func (p *packageParser) addGormModelFields(m *model) {
+	m.table.Columns = append(m.table.Columns,
+		&db.Column{Name: "id", Type: "uint", NotNull: true, PrimaryKey: true},
+		&db.Column{Name: "created_at", Type: "time"},
+		&db.Column{Name: "updated_at", Type: "time"},
+		&db.Column{Name: "deleted_at", Type: "time"},
+	)
+
+	m.fieldColumns["ID"] = "id"
+	m.fieldColumns["CreatedAt"] = "created_at"
+	m.fieldColumns["UpdatedAt"] = "updated_at"
+	m.fieldColumns["DeletedAt"] = "deleted_at"
+	m.primaryFields = append(m.primaryFields, "ID")
+
+	m.addIndexes("deleted_at", tagSettings{{key: "INDEX"}})
+}
+
+func (p *packageParser) buildColumn(fieldName string, fieldType ast.Expr, prefix string, settings tagSettings) *db.Column {
+	columnName, ok := settings.get("COLUMN")
+	if !ok {
+		columnName = toDBName(fieldName)
+	}
+
+	column := &db.Column{
+		Name:       prefix + columnName,
+		NotNull:    settings.has("NOT NULL", "NOTNULL"),
+		PrimaryKey: settings.has("PRIMARYKEY", "PRIMARY_KEY"),
+	}
+
+	if column.PrimaryKey {
+		column.NotNull = true
+	}
+
+	if defaultValue, ok := settings.get("DEFAULT"); ok {
+		column.Default = defaultValue
+	}
+
+	if comment, ok := settings.get("COMMENT"); ok {
+		column.Comment = comment
+	}
+
+	if columnType, ok := settings.get("TYPE"); ok {
+		column.Type = columnType
+		return column
+	}
+
+	dataType := p.dataType(fieldType)
+	if dataType == "" {
+		return nil
+	}
+
+	column.Type = formatColumnType(dataType, settings)
+
+	return column
+}
+
+// dataType returns GORM data type of Go type. This returns empty when type isn't supported
+func (p *packageParser) dataType(fieldType ast.Expr) string {
+	switch t := fieldType.(type) {
+	case *ast.StarExpr:
+		return p.dataType(t.X)
+
+	case *ast.ArrayType:
+		if ident, ok := t.Elt.(*ast.Ident); ok && (ident.Name == "byte" || ident.Name == "uint8") {
+			return "bytes"
+		}
+		return ""
+
+	case *ast.Ident:
+		if dataType, ok := basicTypes[t.Name]; ok {
+			return dataType
+		}
+
+		// e.g. type Status string
+		if underlying, ok := p.typeSpecs[t.Name]; ok {
+			if _, ok := underlying.(*ast.StructType); !ok {
+				return p.dataType(underlying)
+			}
+		}
+		return ""
+
+	case *ast.SelectorExpr:
+		pkg, ok := t.X.(*ast.Ident)
+		if !ok {
+			return ""
+		}
+
+		switch {
+		case pkg.Name == "time" && t.Sel.Name == "Time":
+			return "time"
+		case pkg.Name == "gorm" && t.Sel.Name == "DeletedAt":
+			return "time"
+		case pkg.Name == "sql":
+			return nullableTypes[t.Sel.Name]
+		}
+
+		// e.g. datatypes.JSON, uuid.UUID, decimal.Decimal
+		return strings.ToLower(t.Sel.Name)
+	}
+
+	return ""
+}
+
+func formatColumnType(dataType string, settings tagSettings) string {
+	if precision, ok := settings.get("PRECISION"); ok {
+		if scale, ok := settings.get("SCALE"); ok {
+			return fmt.Sprintf("%s(%s,%s)", dataType, precision, scale)
+		}
+		return fmt.Sprintf("%s(%s)", dataType, precision)
+	}
+
+	if size, ok := settings.get("SIZE"); ok && (dataType == "string" || dataType == "bytes") {
+		return fmt.Sprintf("%s(%s)", dataType, size)
+	}
+
+	return dataType
+}
+
+// addIndexes adds indexes which are declared with index, uniqueIndex and unique tag
+func (m *model) addIndexes(columnName string, settings tagSettings) {
+	for _, setting := range settings {
+		switch setting.key {
+		case "INDEX", "UNIQUEINDEX":
+			m.addIndex(columnName, setting.key == "UNIQUEINDEX", setting.value)
+		case "UNIQUE":
+			m.findOrCreateIndex(fmt.Sprintf("uni_%s_%s", m.table.Name, columnName), true).columns = []*indexColumn{
+				{column: columnName, priority: defaultIndexPriority},
+			}
+		}
+	}
+}
+
+// addIndex parses value of index tag (e.g. idx_name,unique,sort:desc,priority:2)
+func (m *model) addIndex(columnName string, unique bool, value string) {
+	parts := strings.Split(value, ",")
+	name := strings.TrimSpace(parts[0])
+
+	options := tagSettings{}
+	for _, part := range parts[1:] {
+		options = append(options, parseTagSetting(part))
+	}
+
+	if composite, ok := options.get("COMPOSITE"); ok && name == "" {
+		name = fmt.Sprintf("idx_%s_%s", m.table.Name, composite)
+	}
+
+	if name == "" {
+		name = fmt.Sprintf("idx_%s_%s", m.table.Name, columnName)
+	}
+
+	class, _ := options.get("CLASS")
+	unique = unique || options.has("UNIQUE") || strings.EqualFold(class, "UNIQUE")
+
+	definition := m.findOrCreateIndex(name, unique)
+
+	if method, ok := options.get("TYPE"); ok {
+		definition.index.Method = strings.ToLower(method)
+	}
+	if class != "" && !strings.EqualFold(class, "UNIQUE") {
+		// e.g. FULLTEXT, SPATIAL
+		definition.index.Method = strings.ToLower(class)
+	}
+	if where, ok := options.get("WHERE"); ok {
+		definition.index.Predicate = where
+	}
+
+	column := &indexColumn{column: columnName, priority: defaultIndexPriority}
+	if expression, ok := options.get("EXPRESSION"); ok {
+		column = &indexColumn{expression: expression, priority: defaultIndexPriority}
+	}
+
+	if priority, ok := options.get("PRIORITY"); ok {
+		if n, err := strconv.Atoi(priority); err == nil {
+			column.priority = n
+		}
+	}
+	if order, ok := options.get("SORT"); ok {
+		column.order = strings.ToUpper(order)
+	}
+	if length, ok := options.get("LENGTH"); ok {
+		if n, err := strconv.Atoi(length); err == nil {
+			column.length = n
+		}
+	}
+
+	definition.columns = append(definition.columns, column)
+}
+
+func (m *model) findOrCreateIndex(name string, unique bool) *indexDefinition {
+	for _, definition := range m.indexes {
+		if definition.index.Name == name {
+			definition.index.Unique = definition.index.Unique || unique
+			return definition
+		}
+	}
+
+	definition := &indexDefinition{index: &db.Index{Name: name, Unique: unique}}
+	m.indexes = append(m.indexes, definition)
+	return definition
+}
+
+func (m *model) primaryField() string {
+	if len(m.primaryFields) == 0 {
+		return "ID"
+	}
+	return m.primaryFields[0]
+}
+
+func (m *model) primaryColumn() *db.Column {
+	for _, column := range m.table.Columns {
+		if column.Name == m.fieldColumns[m.primaryField()] {
+			return column
+		}
+	}
+	return nil
+}
+
+// applyAssociation adds foreign keys of belongs_to, has_one, has_many and many2many association
+func (p *packageParser) applyAssociation(owner *model, assoc *association, tables map[string]*db.Table) {
+	target, ok := p.models[assoc.structName]
+	if !ok || assoc.settings.has("POLYMORPHIC") {
+		return
+	}
+
+	if joinTable, ok := assoc.settings.get("MANY2MANY"); ok {
+		p.applyMany2Many(owner, target, assoc, joinTable, tables)
+		return
+	}
+
+	foreignKey, hasForeignKey := assoc.settings.get("FOREIGNKEY")
+	references, hasReferences := assoc.settings.get("REFERENCES")
+
+	if !assoc.isSlice {
+		// belongs_to: foreign key field is in owner (e.g. Company Company + CompanyID uint)
+		fieldName := assoc.fieldName + target.primaryField()
+		if hasForeignKey {
+			fieldName = foreignKey
+		}
+
+		if fromColumn, ok := owner.fieldColumns[fieldName]; ok {
+			referenceField := target.primaryField()
+			if hasReferences {
+				referenceField = references
+			}
+
+			if toColumn, ok := target.fieldColumns[referenceField]; ok {
+				addForeignKey(owner.table, &db.ForeignKey{FromColumn: fromColumn, ToTable: target.table.Name, ToColumn: toColumn})
+			}
+			return
+		}
+	}
+
+	// has_one, has_many: foreign key field is in target (e.g. CreditCards []CreditCard + CreditCard.UserID)
+	fieldName := owner.structName + owner.primaryField()
+	if hasForeignKey {
+		fieldName = foreignKey
+	}
+
+	referenceField := owner.primaryField()
+	if hasReferences {
+		referenceField = references
+	}
+
+	fromColumn, ok := target.fieldColumns[fieldName]
+	if !ok {
+		return
+	}
+
+	if toColumn, ok := owner.fieldColumns[referenceField]; ok {
+		addForeignKey(target.table, &db.ForeignKey{FromColumn: fromColumn, ToTable: owner.table.Name, ToColumn: toColumn})
+	}
+}
+
+func (p *packageParser) applyMany2Many(owner *model, target *model, assoc *association, joinTableName string, tables map[string]*db.Table) {
+	ownerPrimaryKey := owner.primaryColumn()
+	targetPrimaryKey := target.primaryColumn()
+	if ownerPrimaryKey == nil || targetPrimaryKey == nil {
+		return
+	}
+
+	ownerColumn := toDBName(owner.structName + owner.primaryField())
+	if joinForeignKey, ok := assoc.settings.get("JOINFOREIGNKEY"); ok {
+		ownerColumn = toDBName(joinForeignKey)
+	}
+
+	targetColumn := toDBName(target.structName + target.primaryField())
+	if owner == target {
+		// e.g. Friends []*User `gorm:"many2many:user_friends"` -> friend_id
+		targetColumn = db.Singularize(toDBName(assoc.fieldName)) + "_" + toDBName(target.primaryField())
+	}
+	if joinReferences, ok := assoc.settings.get("JOINREFERENCES"); ok {
+		targetColumn = toDBName(joinReferences)
+	}
+
+	joinTable, ok := tables[joinTableName]
+	if !ok {
+		joinTable = &db.Table{
+			Name: joinTableName,
+			Columns: []*db.Column{
+				{Name: ownerColumn, Type: ownerPrimaryKey.Type, NotNull: true, PrimaryKey: true},
+				{Name: targetColumn, Type: targetPrimaryKey.Type, NotNull: true, PrimaryKey: true},
+			},
+		}
+		tables[joinTableName] = joinTable
+	}
+
+	addForeignKey(joinTable, &db.ForeignKey{FromColumn: ownerColumn, ToTable: owner.table.Name, ToColumn: ownerPrimaryKey.Name})
+	addForeignKey(joinTable, &db.ForeignKey{FromColumn: targetColumn, ToTable: target.table.Name, ToColumn: targetPrimaryKey.Name})
+}
+
+// addForeignKey adds foreign key to table unless same foreign key is already added
+func addForeignKey(table *db.Table, foreignKey *db.ForeignKey) {
+	for _, existing := range table.ForeignKeys {
+		if *existing == *foreignKey {
+			return
+		}
+	}
+
+	table.ForeignKeys = append(table.ForeignKeys, foreignKey)
+}
+
+// unwrapType returns element type of pointer and slice
+func unwrapType(fieldType ast.Expr) (ast.Expr, bool) {
+	isSlice := false
+
+	for {
+		switch t := fieldType.(type) {
+		case *ast.StarExpr:
+			fieldType = t.X
+		case *ast.ArrayType:
+			if ident, ok := t.Elt.(*ast.Ident); ok && (ident.Name == "byte" || ident.Name == "uint8") {
+				return fieldType, isSlice
+			}
+			isSlice = true
+			fieldType = t.Elt
+		default:
+			return fieldType, isSlice
+		}
+	}
+}
+
+func isGormModel(fieldType ast.Expr) bool {
+	fieldType, _ = unwrapType(fieldType)
+
+	selector, ok := fieldType.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	pkg, ok := selector.X.(*ast.Ident)
+	return ok && pkg.Name == "gorm" && selector.Sel.Name == "Model"
+}
+
+func unquoteTag(value string) string {
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return ""
+	}
+	return unquoted
+}
+
+// parseTag parses gorm tag of field (e.g. `gorm:"column:name;not null;index:idx_name,unique"`)
+func parseTag(field *ast.Field) tagSettings {
+	if field.Tag == nil {
+		return nil
+	}
+
+	tag := reflect.StructTag(unquoteTag(field.Tag.Value)).Get("gorm")
+	if tag == "" {
+		return nil
+	}
+
+	var settings tagSettings
+	for _, part := range strings.Split(tag, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+
+		settings = append(settings, parseTagSetting(part))
+	}
+
+	return settings
+}
+
+// parseTagSetting parses `key:value` of tag. key is upper-cased like GORM
+func parseTagSetting(str string) tagSetting {
+	key, value, _ := strings.Cut(str, ":")
+
+	key = strings.ToUpper(strings.TrimSpace(key))
+	if strings.HasPrefix(key, "-") {
+		// e.g. -:all, -:migration
+		key = "-"
+	}
+
+	return tagSetting{key: key, value: strings.TrimSpace(value)}
+}
+
+// toDBName converts Go name to snake case like GORM naming strategy (e.g. UserID -> user_id, HTTPServer -> http_server)
+func toDBName(name string) string {
+	runes := []rune(name)
+
+	var builder strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || unicode.IsUpper(prev) && nextIsLower {
+				builder.WriteRune('_')
+			}
+		}
+		builder.WriteRune(unicode.ToLower(r))
+	}
+
+	return builder.String()
+}
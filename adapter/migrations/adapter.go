@@ -0,0 +1,65 @@
+package migrations
+
+import (
+	"fmt"
+	"github.com/cockroachdb/errors"
+	"github.com/sue445/plant_erd/adapter/sqlite3"
+	"os"
+	"path/filepath"
+)
+
+// NewAdapter applies migrations in dir to a temporary database and returns adapter for it.
+// When until isn't empty, only migrations whose version is less than or equal to until are applied
+func NewAdapter(dir string, dialect string, until string) (*sqlite3.Adapter, sqlite3.Close, error) {
+	if dialect != "sqlite" {
+		return nil, nil, fmt.Errorf("%s is unsupported dialect", dialect)
+	}
+
+	migrations, err := findMigrations(dir)
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "plant_erd")
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	adapter, closeDatabase, err := sqlite3.NewAdapter(filepath.Join(tmpDir, "migrations.sqlite3"))
+
+	if err != nil {
+		_ = os.RemoveAll(tmpDir)
+		return nil, nil, errors.WithStack(err)
+	}
+
+	cleanup := func() error {
+		err := closeDatabase()
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		return errors.WithStack(os.RemoveAll(tmpDir))
+	}
+
+	for _, m := range migrations {
+		if until != "" && m.version != "" && compareVersion(m.version, until) > 0 {
+			continue
+		}
+
+		sql, err := m.readUpSQL()
+		if err != nil {
+			_ = cleanup()
+			return nil, nil, errors.WithStack(err)
+		}
+
+		_, err = adapter.DB.Exec(sql)
+		if err != nil {
+			_ = cleanup()
+			return nil, nil, errors.Wrapf(err, "failed to apply %s", filepath.Base(m.filename))
+		}
+	}
+
+	return adapter, cleanup, nil
+}
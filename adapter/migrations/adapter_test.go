@@ -0,0 +1,158 @@
+package migrations
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sue445/plant_erd/db"
+)
+
+func writeMigrations(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+
+	for name, content := range files {
+		err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644)
+		require.NoError(t, err)
+	}
+
+	return dir
+}
+
+var golangMigrateFiles = map[string]string{
+	"1_create_users.up.sql":      "CREATE TABLE users (id integer not null primary key, name text);",
+	"1_create_users.down.sql":    "DROP TABLE users;",
+	"2_create_articles.up.sql":   "CREATE TABLE articles (id integer not null primary key, user_id integer not null, FOREIGN KEY(user_id) REFERENCES users(id));",
+	"2_create_articles.down.sql": "DROP TABLE articles;",
+	"10_add_title.up.sql":        "ALTER TABLE articles ADD COLUMN title text;\nCREATE INDEX index_title_on_articles ON articles(title);",
+	"10_add_title.down.sql":      "DROP INDEX index_title_on_articles;",
+	"README.md":                  "# migrations",
+}
+
+func TestNewAdapter(t *testing.T) {
+	dir := writeMigrations(t, golangMigrateFiles)
+
+	adapter, closeDatabase, err := NewAdapter(dir, "sqlite", "")
+	require.NoError(t, err)
+
+	defer closeDatabase() //nolint:errcheck
+
+	tables, err := adapter.GetAllTableNames()
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"articles", "users"}, tables)
+	}
+
+	table, err := adapter.GetTable("articles")
+	if assert.NoError(t, err) {
+		want := &db.Table{
+			Name: "articles",
+			Columns: []*db.Column{
+				{Name: "id", Type: "INTEGER", NotNull: true, PrimaryKey: true},
+				{Name: "user_id", Type: "INTEGER", NotNull: true},
+				{Name: "title", Type: "TEXT"},
+			},
+			ForeignKeys: []*db.ForeignKey{
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+			},
+			Indexes: []*db.Index{
+				{Name: "index_title_on_articles", Columns: []string{"title"}},
+			},
+		}
+		assert.Equal(t, want, table)
+	}
+}
+
+func TestNewAdapter_Until(t *testing.T) {
+	dir := writeMigrations(t, golangMigrateFiles)
+
+	adapter, closeDatabase, err := NewAdapter(dir, "sqlite", "2")
+	require.NoError(t, err)
+
+	defer closeDatabase() //nolint:errcheck
+
+	table, err := adapter.GetTable("articles")
+	if assert.NoError(t, err) {
+		assert.Len(t, table.Columns, 2)
+		assert.Empty(t, table.Indexes)
+	}
+}
+
+func TestNewAdapter_Goose(t *testing.T) {
+	dir := writeMigrations(t, map[string]string{
+		"20240101000000_create_users.sql": "-- +goose Up\nCREATE TABLE users (id integer not null primary key);\n\n-- +goose Down\nDROP TABLE users;\n",
+	})
+
+	adapter, closeDatabase, err := NewAdapter(dir, "sqlite", "")
+	require.NoError(t, err)
+
+	defer closeDatabase() //nolint:errcheck
+
+	tables, err := adapter.GetAllTableNames()
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"users"}, tables)
+	}
+}
+
+func TestNewAdapter_Error(t *testing.T) {
+	t.Run("unsupported dialect", func(t *testing.T) {
+		_, _, err := NewAdapter(t.TempDir(), "mysql", "")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "mysql is unsupported dialect")
+	})
+
+	t.Run("invalid SQL", func(t *testing.T) {
+		dir := writeMigrations(t, map[string]string{
+			"1_invalid.up.sql": "CREATE TABLE;",
+		})
+
+		_, _, err := NewAdapter(dir, "sqlite", "")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to apply 1_invalid.up.sql")
+	})
+}
+
+func Test_findMigrations(t *testing.T) {
+	dir := writeMigrations(t, map[string]string{
+		"V1__init.sql":          "",
+		"V1_10__add_column.sql": "",
+		"V1.2__add_index.sql":   "",
+		"U1__init.sql":          "",
+		"R__views.sql":          "",
+		"V2__create_logs.sql":   "",
+	})
+
+	migrations, err := findMigrations(dir)
+	require.NoError(t, err)
+
+	var names []string
+	for _, m := range migrations {
+		names = append(names, m.name)
+	}
+
+	assert.Equal(t, []string{"init", "add_index", "add_column", "create_logs", "views"}, names)
+}
+
+func Test_compareVersion(t *testing.T) {
+	tests := []struct {
+		a    string
+		b    string
+		want int
+	}{
+		{a: "1", b: "1", want: 0},
+		{a: "2", b: "10", want: -1},
+		{a: "010", b: "9", want: 1},
+		{a: "1.2", b: "1.10", want: -1},
+		{a: "1_1", b: "1.1", want: 0},
+		{a: "1", b: "1.0", want: 0},
+		{a: "20240102000000", b: "20240101000000", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+" "+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.want, compareVersion(tt.a, tt.b))
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package migrations
+
+import (
+	"github.com/cockroachdb/errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// golang-migrate: 1_create_users.up.sql
+	golangMigrateRe = regexp.MustCompile(`^(\d+)_(.*)\.up\.sql$`)
+
+	// Flyway: V1_1__create_users.sql
+	flywayRe = regexp.MustCompile(`^V(\d+(?:[._]\d+)*)__(.*)\.sql$`)
+
+	// Flyway repeatable migration: R__create_views.sql
+	flywayRepeatableRe = regexp.MustCompile(`^R__(.*)\.sql$`)
+
+	// goose: 20240101000000_create_users.sql
+	gooseRe = regexp.MustCompile(`^(\d+)_(.*)\.sql$`)
+
+	versionSeparatorRe = regexp.MustCompile(`[._]`)
+)
+
+const (
+	gooseUpAnnotation   = "-- +goose Up"
+	gooseDownAnnotation = "-- +goose Down"
+)
+
+// migration represents migration file
+type migration struct {
+	// version represents migration version. This is empty for repeatable migration
+	version  string
+	name     string
+	filename string
+}
+
+// findMigrations returns migrations in dir which are sorted by version.
+// Repeatable migrations are sorted by name and placed after versioned migrations (same as Flyway)
+func findMigrations(dir string) ([]*migration, error) {
+	entries, err := os.ReadDir(dir)
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var versioned []*migration
+	var repeatable []*migration
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+		filename := filepath.Join(dir, name)
+
+		if strings.HasSuffix(name, ".down.sql") {
+			continue
+		}
+
+		if matched := golangMigrateRe.FindStringSubmatch(name); matched != nil {
+			versioned = append(versioned, &migration{version: matched[1], name: matched[2], filename: filename})
+			continue
+		}
+
+		if matched := flywayRe.FindStringSubmatch(name); matched != nil {
+			versioned = append(versioned, &migration{version: matched[1], name: matched[2], filename: filename})
+			continue
+		}
+
+		if matched := flywayRepeatableRe.FindStringSubmatch(name); matched != nil {
+			repeatable = append(repeatable, &migration{name: matched[1], filename: filename})
+			continue
+		}
+
+		if matched := gooseRe.FindStringSubmatch(name); matched != nil {
+			versioned = append(versioned, &migration{version: matched[1], name: matched[2], filename: filename})
+		}
+	}
+
+	sort.SliceStable(versioned, func(i, j int) bool {
+		return compareVersion(versioned[i].version, versioned[j].version) < 0
+	})
+
+	sort.SliceStable(repeatable, func(i, j int) bool {
+		return repeatable[i].name < repeatable[j].name
+	})
+
+	return append(versioned, repeatable...), nil
+}
+
+// compareVersion compares versions numerically (e.g. 2 < 10, 1.2 < 1.10, 1_1 == 1.1)
+func compareVersion(a string, b string) int {
+	aParts := versionSeparatorRe.Split(a, -1)
+	bParts := versionSeparatorRe.Split(b, -1)
+
+	for i := 0; i < len(aParts) || i < len(bParts); i++ {
+		aPart := ""
+		if i < len(aParts) {
+			aPart = strings.TrimLeft(aParts[i], "0")
+		}
+
+		bPart := ""
+		if i < len(bParts) {
+			bPart = strings.TrimLeft(bParts[i], "0")
+		}
+
+		// Compare as numbers without overflow (e.g. timestamp versions)
+		if len(aPart) != len(bPart) {
+			if len(aPart) < len(bPart) {
+				return -1
+			}
+			return 1
+		}
+
+		if c := strings.Compare(aPart, bPart); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+// readUpSQL returns SQL which migrates database up. Down section of goose migration is excluded
+func (m *migration) readUpSQL() (string, error) {
+	content, err := os.ReadFile(m.filename)
+
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	sql := string(content)
+
+	upIndex := strings.Index(sql, gooseUpAnnotation)
+	if upIndex < 0 {
+		return sql, nil
+	}
+
+	sql = sql[upIndex+len(gooseUpAnnotation):]
+
+	if downIndex := strings.Index(sql, gooseDownAnnotation); downIndex >= 0 {
+		sql = sql[:downIndex]
+	}
+
+	return sql, nil
+}
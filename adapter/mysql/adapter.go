@@ -38,6 +38,20 @@ func rowInt(row map[string]interface{}, columnName string) int64 {
 	return row[columnName].(int64)
 }
 
+func rowNullableString(row map[string]interface{}, columnName string) string {
+	if row[columnName] == nil {
+		return ""
+	}
+	return rowString(row, columnName)
+}
+
+func rowNullableInt(row map[string]interface{}, columnName string) int64 {
+	if row[columnName] == nil {
+		return 0
+	}
+	return rowInt(row, columnName)
+}
+
 // GetAllTableNames returns all table names in database
 func (a *Adapter) GetAllTableNames() ([]string, error) {
 	var rows []informationSchemaTables
@@ -61,7 +75,7 @@ func (a *Adapter) GetTable(tableName string) (*db.Table, error) {
 		Name: tableName,
 	}
 
-	rows, err := a.db.Queryx(fmt.Sprintf("SHOW COLUMNS FROM %s", tableName))
+	rows, err := a.db.Queryx(fmt.Sprintf("SHOW FULL COLUMNS FROM %s", tableName))
 
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -80,6 +94,8 @@ func (a *Adapter) GetTable(tableName string) (*db.Table, error) {
 			Type:       rowString(row, "Type"),
 			NotNull:    rowString(row, "Null") == "NO",
 			PrimaryKey: rowString(row, "Key") == "PRI",
+			Default:    rowNullableString(row, "Default"),
+			Comment:    rowString(row, "Comment"),
 		}
 
 		table.Columns = append(table.Columns, column)
@@ -99,9 +115,118 @@ func (a *Adapter) GetTable(tableName string) (*db.Table, error) {
 
 	table.Indexes = indexes
 
+	triggers, err := a.getTriggers(tableName)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	table.Triggers = triggers
+
+	partitionKey, err := a.getPartitionKey(tableName)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	table.PartitionKey = partitionKey
+
 	return &table, nil
 }
 
+func (a *Adapter) getTriggers(tableName string) ([]*db.Trigger, error) {
+	var rows []informationSchemaTriggers
+
+	sql := `
+			SELECT trigger_name AS 'trigger_name',
+			       event_manipulation AS 'event_manipulation',
+			       action_timing AS 'action_timing',
+			       action_statement AS 'action_statement'
+			FROM information_schema.triggers
+			WHERE event_object_schema = database()
+			  AND event_object_table = ?
+			ORDER BY trigger_name
+            `
+
+	err := a.db.Select(&rows, sql, tableName)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var triggers []*db.Trigger
+	for _, row := range rows {
+		trigger := &db.Trigger{
+			Name:   row.TriggerName,
+			Event:  row.EventManipulation,
+			Timing: row.ActionTiming,
+			Body:   row.ActionStatement,
+		}
+		triggers = append(triggers, trigger)
+	}
+
+	return triggers, nil
+}
+
+// GetTableStats returns estimated row count and size of table
+func (a *Adapter) GetTableStats(tableName string) (*db.TableStats, error) {
+	var row informationSchemaTableStats
+
+	// NOTE: table_rows is an estimated value on InnoDB
+	sql := `
+			SELECT COALESCE(table_rows, 0) AS 'table_rows',
+			       COALESCE(data_length, 0) + COALESCE(index_length, 0) AS 'size'
+			FROM information_schema.tables
+			WHERE table_schema = database()
+			  AND table_name = ?
+            `
+
+	err := a.db.Get(&row, sql, tableName)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &db.TableStats{Rows: row.TableRows, Size: row.Size}, nil
+}
+
+// GetColumnValues returns up to limit distinct values of column
+func (a *Adapter) GetColumnValues(tableName string, columnName string, limit int) ([]string, error) {
+	var values []string
+
+	sql := fmt.Sprintf("SELECT DISTINCT CAST(`%s` AS CHAR) FROM `%s` WHERE `%s` IS NOT NULL ORDER BY 1 LIMIT %d", columnName, tableName, columnName, limit)
+	err := a.db.Select(&values, sql)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return values, nil
+}
+
+func (a *Adapter) getPartitionKey(tableName string) (string, error) {
+	var rows []informationSchemaPartitions
+
+	// NOTE: partition_method and partition_expression are NULL when table isn't partitioned
+	sql := `
+			SELECT DISTINCT partition_method AS 'partition_method',
+			       partition_expression AS 'partition_expression'
+			FROM information_schema.partitions
+			WHERE table_schema = database()
+			  AND table_name = ?
+			  AND partition_name IS NOT NULL
+            `
+
+	err := a.db.Select(&rows, sql, tableName)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	for _, row := range rows {
+		if row.PartitionMethod.Valid {
+			expression := strings.ReplaceAll(row.PartitionExpression.String, "`", "")
+			return fmt.Sprintf("%s (%s)", row.PartitionMethod.String, expression), nil
+		}
+	}
+
+	return "", nil
+}
+
 func (a *Adapter) getForeignKeys(tableName string) ([]*db.ForeignKey, error) {
 	var rows []infomationSchemaKeyColumnUsage
 
@@ -128,6 +253,7 @@ func (a *Adapter) getForeignKeys(tableName string) ([]*db.ForeignKey, error) {
 			FromColumn: row.Column,
 			ToColumn:   row.PrimaryKey,
 			ToTable:    row.ToTable,
+			Name:       row.Name,
 		}
 
 		foreignKeys = append(foreignKeys, foreignKey)
@@ -176,13 +302,29 @@ func (a *Adapter) getIndexes(tableName string) ([]*db.Index, error) {
 			index := db.Index{
 				Name:   keyName,
 				Unique: rowInt(row, "Non_unique") == 0,
+				Method: strings.ToLower(rowString(row, "Index_type")),
 			}
 			indexes = append(indexes, &index)
 			currentIndex = keyName
 		}
 
-		last := len(indexes) - 1
-		indexes[last].Columns = append(indexes[last].Columns, rowString(row, "Column_name"))
+		index := indexes[len(indexes)-1]
+
+		// Column_name is NULL and Expression is set when functional key part (MySQL 8.0.13+)
+		if columnName := rowNullableString(row, "Column_name"); columnName != "" {
+			index.AddColumn(columnName)
+		} else {
+			index.AddExpression(rowNullableString(row, "Expression"))
+		}
+
+		if subPart := rowNullableInt(row, "Sub_part"); subPart > 0 {
+			index.SetLength(int(subPart))
+		}
+
+		// Collation is "D" when descending index (MySQL 8+)
+		if rowNullableString(row, "Collation") == "D" {
+			index.SetOrder("DESC")
+		}
 	}
 
 	return indexes, nil
@@ -35,6 +35,8 @@ func withDatabase(callback func(*Adapter)) {
 
 	defer closeDatabase() //nolint:errcheck
 
+	adapter.db.MustExec("DROP TABLE IF EXISTS events;")
+	adapter.db.MustExec("DROP TABLE IF EXISTS profiles;")
 	adapter.db.MustExec("DROP TABLE IF EXISTS followers;")
 	adapter.db.MustExec("DROP TABLE IF EXISTS articles;")
 	adapter.db.MustExec("DROP TABLE IF EXISTS users;")
@@ -91,7 +93,7 @@ func TestAdapter_GetTable(t *testing.T) {
 			CREATE TABLE articles (
 				id      int not null primary key,
 				user_id int not null,
-				FOREIGN KEY fk_users (user_id) REFERENCES users(id)
+				CONSTRAINT fk_articles_user_id FOREIGN KEY fk_users (user_id) REFERENCES users(id)
 		);`)
 		defer func() {
 			a.db.MustExec("DROP TABLE articles;")
@@ -103,8 +105,8 @@ func TestAdapter_GetTable(t *testing.T) {
 				id             int not null primary key,
 				user_id        int not null,
 				target_user_id int not null,
-				FOREIGN KEY fk_users (user_id)         REFERENCES users(id),
-				FOREIGN KEY fk_users2 (target_user_id) REFERENCES users(id)
+				CONSTRAINT fk_followers_user_id        FOREIGN KEY fk_users (user_id)         REFERENCES users(id),
+				CONSTRAINT fk_followers_target_user_id FOREIGN KEY fk_users2 (target_user_id) REFERENCES users(id)
 		);`)
 		defer func() {
 			a.db.MustExec("DROP TABLE followers;")
@@ -112,6 +114,18 @@ func TestAdapter_GetTable(t *testing.T) {
 		a.db.MustExec("CREATE UNIQUE INDEX index_user_id_and_target_user_id_on_followers ON followers(user_id, target_user_id)")
 		a.db.MustExec("CREATE UNIQUE INDEX index_target_user_id_and_user_id_on_followers ON followers(target_user_id, user_id)")
 
+		a.db.MustExec(`
+			CREATE TABLE profiles (
+				id          int not null primary key,
+				screen_name varchar(191) not null default 'anonymous',
+				bio         text comment 'Self introduction'
+		);`)
+		defer func() {
+			a.db.MustExec("DROP TABLE profiles;")
+		}()
+		a.db.MustExec("CREATE INDEX index_screen_name_on_profiles ON profiles(screen_name(10))")
+		a.db.MustExec("CREATE FULLTEXT INDEX index_bio_on_profiles ON profiles(bio)")
+
 		type args struct {
 			tableName string
 		}
@@ -166,6 +180,7 @@ func TestAdapter_GetTable(t *testing.T) {
 							FromColumn: "user_id",
 							ToTable:    "users",
 							ToColumn:   "id",
+							Name:       "fk_articles_user_id",
 						},
 					},
 					Indexes: []*db.Index{
@@ -173,6 +188,7 @@ func TestAdapter_GetTable(t *testing.T) {
 							Name:    "index_user_id_on_articles",
 							Columns: []string{"user_id"},
 							Unique:  false,
+							Method:  "btree",
 						},
 					},
 				},
@@ -207,11 +223,13 @@ func TestAdapter_GetTable(t *testing.T) {
 							FromColumn: "target_user_id",
 							ToTable:    "users",
 							ToColumn:   "id",
+							Name:       "fk_followers_target_user_id",
 						},
 						{
 							FromColumn: "user_id",
 							ToTable:    "users",
 							ToColumn:   "id",
+							Name:       "fk_followers_user_id",
 						},
 					},
 					Indexes: []*db.Index{
@@ -219,11 +237,54 @@ func TestAdapter_GetTable(t *testing.T) {
 							Name:    "index_user_id_and_target_user_id_on_followers",
 							Columns: []string{"user_id", "target_user_id"},
 							Unique:  true,
+							Method:  "btree",
 						},
 						{
 							Name:    "index_target_user_id_and_user_id_on_followers",
 							Columns: []string{"target_user_id", "user_id"},
 							Unique:  true,
+							Method:  "btree",
+						},
+					},
+				},
+			},
+			{
+				name: "profiles",
+				args: args{
+					tableName: "profiles",
+				},
+				want: &db.Table{
+					Name: "profiles",
+					Columns: []*db.Column{
+						{
+							Name:       "id",
+							Type:       "int",
+							NotNull:    true,
+							PrimaryKey: true,
+						},
+						{
+							Name:    "screen_name",
+							Type:    "varchar(191)",
+							NotNull: true,
+							Default: "anonymous",
+						},
+						{
+							Name:    "bio",
+							Type:    "text",
+							Comment: "Self introduction",
+						},
+					},
+					Indexes: []*db.Index{
+						{
+							Name:    "index_screen_name_on_profiles",
+							Columns: []string{"screen_name"},
+							Method:  "btree",
+							Lengths: []int{10},
+						},
+						{
+							Name:    "index_bio_on_profiles",
+							Columns: []string{"bio"},
+							Method:  "fulltext",
 						},
 					},
 				},
@@ -263,3 +324,27 @@ func TestAdapter_GetTable(t *testing.T) {
 		}
 	})
 }
+
+func TestAdapter_GetTable_with_partitioned_table(t *testing.T) {
+	withDatabase(func(a *Adapter) {
+		a.db.MustExec(`
+			CREATE TABLE events (
+				id         int not null,
+				created_at date not null,
+				PRIMARY KEY (id, created_at)
+			)
+			PARTITION BY RANGE COLUMNS(created_at) (
+				PARTITION p2024 VALUES LESS THAN ('2025-01-01'),
+				PARTITION p2025 VALUES LESS THAN ('2026-01-01')
+		);`)
+		defer func() {
+			a.db.MustExec("DROP TABLE events;")
+		}()
+
+		got, err := a.GetTable("events")
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, "RANGE COLUMNS (created_at)", got.PartitionKey)
+		}
+	})
+}
@@ -1,5 +1,7 @@
 package mysql
 
+import "database/sql"
+
 type informationSchemaTables struct {
 	TableName string `db:"table_name"`
 }
@@ -10,3 +12,20 @@ type infomationSchemaKeyColumnUsage struct {
 	Column     string `db:"column"`
 	Name       string `db:"name"`
 }
+
+type informationSchemaPartitions struct {
+	PartitionMethod     sql.NullString `db:"partition_method"`
+	PartitionExpression sql.NullString `db:"partition_expression"`
+}
+
+type informationSchemaTableStats struct {
+	TableRows int64 `db:"table_rows"`
+	Size      int64 `db:"size"`
+}
+
+type informationSchemaTriggers struct {
+	TriggerName       string `db:"trigger_name"`
+	EventManipulation string `db:"event_manipulation"`
+	ActionTiming      string `db:"action_timing"`
+	ActionStatement   string `db:"action_statement"`
+}
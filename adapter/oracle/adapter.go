@@ -1,13 +1,18 @@
 package oracle
 
 import (
+	"fmt"
 	"github.com/cockroachdb/errors"
 	mapset "github.com/deckarep/golang-set/v2"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/mattn/go-oci8" // for sql
 	"github.com/sue445/plant_erd/db"
+	"regexp"
+	"strings"
 )
 
+var quotedIdentifierRe = regexp.MustCompile(`^"[^"]+"$`)
+
 // Adapter represents Oracle adapter
 type Adapter struct {
 	db *sqlx.DB
@@ -66,10 +71,12 @@ func (a *Adapter) GetTable(tableName string) (*db.Table, error) {
 	}
 
 	sql := `
-		SELECT COLUMN_NAME, DATA_TYPE, DATA_LENGTH, DATA_PRECISION, DATA_SCALE, NULLABLE
-		FROM ALL_TAB_COLUMNS
-		WHERE TABLE_NAME = UPPER(?)
-		AND owner = SYS_CONTEXT('userenv', 'current_schema')
+		SELECT c.COLUMN_NAME, c.DATA_TYPE, c.DATA_LENGTH, c.DATA_PRECISION, c.DATA_SCALE, c.NULLABLE, cc.COMMENTS
+		FROM ALL_TAB_COLUMNS c
+		LEFT JOIN ALL_COL_COMMENTS cc
+		  ON cc.OWNER = c.OWNER AND cc.TABLE_NAME = c.TABLE_NAME AND cc.COLUMN_NAME = c.COLUMN_NAME
+		WHERE c.TABLE_NAME = UPPER(?)
+		AND c.owner = SYS_CONTEXT('userenv', 'current_schema')
 	`
 	stmt, err := a.db.Preparex(a.db.Rebind(sql))
 	if err != nil {
@@ -90,6 +97,7 @@ func (a *Adapter) GetTable(tableName string) (*db.Table, error) {
 			Type:       row.FormatColumnType(),
 			NotNull:    row.Nullable == "N",
 			PrimaryKey: primaryKeyColumns.Contains(row.ColumnName),
+			Comment:    row.Comments.String,
 		}
 		table.Columns = append(table.Columns, column)
 	}
@@ -106,6 +114,18 @@ func (a *Adapter) GetTable(tableName string) (*db.Table, error) {
 	}
 	table.Indexes = indexes
 
+	triggers, err := a.getTriggers(tableName)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	table.Triggers = triggers
+
+	partitionKey, err := a.getPartitionKey(tableName)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	table.PartitionKey = partitionKey
+
 	return &table, nil
 }
 
@@ -143,12 +163,132 @@ func (a *Adapter) getPrimaryKeyColumns(tableName string) (mapset.Set[string], er
 	return columns, nil
 }
 
+func (a *Adapter) getTriggers(tableName string) ([]*db.Trigger, error) {
+	sql := `
+		SELECT trigger_name, trigger_type, triggering_event, trigger_body
+		FROM all_triggers
+		WHERE table_owner = SYS_CONTEXT('userenv', 'current_schema')
+		AND table_name = UPPER(?)
+		ORDER BY trigger_name
+	`
+
+	stmt, err := a.db.Preparex(a.db.Rebind(sql))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var rows []allTriggers
+	err = stmt.Select(&rows, tableName)
+	defer stmt.Close()
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var triggers []*db.Trigger
+	for _, row := range rows {
+		trigger := &db.Trigger{
+			Name:   row.TriggerName,
+			Event:  row.TriggeringEvent,
+			Timing: row.Timing(),
+			Body:   row.TriggerBody,
+		}
+		triggers = append(triggers, trigger)
+	}
+
+	return triggers, nil
+}
+
+// GetTableStats returns estimated row count and size of table
+func (a *Adapter) GetTableStats(tableName string) (*db.TableStats, error) {
+	// NOTE: num_rows and avg_row_len are collected by DBMS_STATS (NULL until gathered)
+	sql := `
+		SELECT NVL(num_rows, 0) AS num_rows, NVL(num_rows, 0) * NVL(avg_row_len, 0) AS table_size
+		FROM all_tables
+		WHERE owner = SYS_CONTEXT('userenv', 'current_schema')
+		AND table_name = UPPER(?)
+	`
+
+	stmt, err := a.db.Preparex(a.db.Rebind(sql))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var rows []allTableStats
+	err = stmt.Select(&rows, tableName)
+	defer stmt.Close()
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	stats := &db.TableStats{}
+	if len(rows) > 0 {
+		stats.Rows = rows[0].NumRows
+		stats.Size = rows[0].TableSize
+	}
+
+	return stats, nil
+}
+
+// GetColumnValues returns up to limit distinct values of column
+func (a *Adapter) GetColumnValues(tableName string, columnName string, limit int) ([]string, error) {
+	var values []string
+
+	// NOTE: ROWNUM is assigned before DISTINCT and ORDER BY, so values are limited in outer query
+	sql := fmt.Sprintf(`
+		SELECT value FROM (
+			SELECT DISTINCT TO_CHAR(%s) AS value FROM %s WHERE %s IS NOT NULL ORDER BY 1
+		) WHERE ROWNUM <= %d
+	`, quoteIdentifier(columnName), quoteIdentifier(tableName), quoteIdentifier(columnName), limit)
+	err := a.db.Select(&values, sql)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return values, nil
+}
+
+func (a *Adapter) getPartitionKey(tableName string) (string, error) {
+	sql := `
+		SELECT p.partitioning_type,
+		       LISTAGG(k.column_name, ', ') WITHIN GROUP (ORDER BY k.column_position) AS partition_columns
+		FROM all_part_tables p, all_part_key_columns k
+		WHERE p.owner = SYS_CONTEXT('userenv', 'current_schema')
+		AND p.table_name = UPPER(?)
+		AND k.owner = p.owner
+		AND k.name = p.table_name
+		AND k.object_type = 'TABLE'
+		GROUP BY p.partitioning_type
+	`
+
+	stmt, err := a.db.Preparex(a.db.Rebind(sql))
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	var rows []allPartTables
+	err = stmt.Select(&rows, tableName)
+	defer stmt.Close()
+
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	if len(rows) == 0 {
+		return "", nil
+	}
+
+	return fmt.Sprintf("%s (%s)", rows[0].PartitioningType, rows[0].PartitionColumns), nil
+}
+
 func (a *Adapter) getForeignKeys(tableName string) ([]*db.ForeignKey, error) {
 	// c.f. https://github.com/rsim/oracle-enhanced/blob/v6.0.0/lib/active_record/connection_adapters/oracle_enhanced/schema_statements.rb#L544
 	sql := `
             SELECT r.table_name to_table
                   ,rc.column_name references_column
                   ,cc.column_name
+                  ,c.constraint_name
               FROM all_constraints c, all_cons_columns cc,
                    all_constraints r, all_cons_columns rc
              WHERE c.owner = SYS_CONTEXT('userenv', 'current_schema')
@@ -184,6 +324,7 @@ func (a *Adapter) getForeignKeys(tableName string) ([]*db.ForeignKey, error) {
 			FromColumn: row.ColumnName,
 			ToColumn:   row.ReferencesColumn,
 			ToTable:    row.ToTable,
+			Name:       row.ConstraintName,
 		}
 		foreignKeys = append(foreignKeys, foreignKey)
 	}
@@ -194,7 +335,7 @@ func (a *Adapter) getForeignKeys(tableName string) ([]*db.ForeignKey, error) {
 func (a *Adapter) getIndexes(tableName string) ([]*db.Index, error) {
 	// c.f. https://github.com/rsim/oracle-enhanced/blob/v6.0.0/lib/active_record/connection_adapters/oracle_enhanced/schema_statements.rb#L91
 	sql := `
-		SELECT index_name, uniqueness
+		SELECT index_name, uniqueness, index_type
 		FROM all_indexes i
 		WHERE owner = SYS_CONTEXT('userenv', 'current_schema') 
 		AND table_owner = SYS_CONTEXT('userenv', 'current_schema') 
@@ -221,44 +362,94 @@ func (a *Adapter) getIndexes(tableName string) ([]*db.Index, error) {
 	}
 	var indexes []*db.Index
 	for _, row := range rows {
-		columns, err := a.getIndexColumns(row.IndexName)
+		index := &db.Index{
+			Name:   row.IndexName,
+			Unique: row.Uniqueness == "UNIQUE",
+			Method: row.FormatIndexMethod(),
+		}
+
+		err := a.loadIndexColumns(index)
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
 
-		index := &db.Index{
-			Name:    row.IndexName,
-			Unique:  row.Uniqueness == "UNIQUE",
-			Columns: columns,
-		}
 		indexes = append(indexes, index)
 	}
 
 	return indexes, nil
 }
 
-func (a *Adapter) getIndexColumns(indexName string) ([]string, error) {
+func (a *Adapter) loadIndexColumns(index *db.Index) error {
+	expressions, err := a.getIndexExpressions(index.Name)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
 	// c.f. https://github.com/rsim/oracle-enhanced/blob/v6.0.0/lib/active_record/connection_adapters/oracle_enhanced/schema_statements.rb#L91
-	sql := "SELECT column_name FROM all_ind_columns WHERE index_name = ? ORDER BY column_position"
+	sql := "SELECT column_name, column_position, descend FROM all_ind_columns WHERE index_name = ? ORDER BY column_position"
 
 	stmt, err := a.db.Preparex(a.db.Rebind(sql))
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return errors.WithStack(err)
 	}
 
 	var rows []allIndColumns
-	err = stmt.Select(&rows, indexName)
+	err = stmt.Select(&rows, index.Name)
 	defer stmt.Close()
 
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, row := range rows {
+		columnName := row.ColumnName
+
+		// column_name is a system-generated name (e.g. SYS_NC00003$) when key is an expression or descending column
+		if expression, ok := expressions[row.ColumnPosition]; ok {
+			if !quotedIdentifierRe.MatchString(expression) {
+				index.AddExpression(expression)
+				continue
+			}
+
+			columnName = strings.Trim(expression, `"`)
+		}
+
+		index.AddColumn(columnName)
+
+		if row.Descend == "DESC" {
+			index.SetOrder("DESC")
+		}
+	}
+
+	return nil
+}
+
+func (a *Adapter) getIndexExpressions(indexName string) (map[int]string, error) {
+	sql := "SELECT column_expression, column_position FROM all_ind_expressions WHERE index_name = ? ORDER BY column_position"
+
+	stmt, err := a.db.Preparex(a.db.Rebind(sql))
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
 
-	var columns []string
+	var rows []allIndExpressions
+	err = stmt.Select(&rows, indexName)
+	defer stmt.Close()
 
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	expressions := map[int]string{}
 	for _, row := range rows {
-		columns = append(columns, row.ColumnName)
+		expressions[row.ColumnPosition] = row.ColumnExpression
 	}
 
-	return columns, nil
+	return expressions, nil
+}
+
+// quoteIdentifier returns identifier which is quoted with double quotes.
+// identifier is upcased in the same way as UPPER(?) of dictionary lookups, because quoted identifier is case-sensitive in Oracle
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(strings.ToUpper(identifier), `"`, `""`) + `"`
 }
@@ -90,7 +90,7 @@ func TestAdapter_GetTable(t *testing.T) {
 			CREATE TABLE articles (
 				id      integer not null primary key,
 				user_id integer not null,
-				FOREIGN KEY(user_id) REFERENCES users(id)
+				CONSTRAINT fk_articles_user_id FOREIGN KEY(user_id) REFERENCES users(id)
 		)`)
 		defer func() {
 			a.db.MustExec("DROP TABLE articles")
@@ -102,8 +102,8 @@ func TestAdapter_GetTable(t *testing.T) {
 				id             integer not null primary key,
 				user_id        integer not null,
 				target_user_id integer not null,
-				FOREIGN KEY(user_id)        REFERENCES users(id),
-				FOREIGN KEY(target_user_id) REFERENCES users(id)
+				CONSTRAINT fk_followers_user_id        FOREIGN KEY(user_id)        REFERENCES users(id),
+				CONSTRAINT fk_followers_target_user_id FOREIGN KEY(target_user_id) REFERENCES users(id)
 		)`)
 		defer func() {
 			a.db.MustExec("DROP TABLE followers")
@@ -165,6 +165,7 @@ func TestAdapter_GetTable(t *testing.T) {
 							FromColumn: "USER_ID",
 							ToTable:    "USERS",
 							ToColumn:   "ID",
+							Name:       "FK_ARTICLES_USER_ID",
 						},
 					},
 					Indexes: []*db.Index{
@@ -172,6 +173,7 @@ func TestAdapter_GetTable(t *testing.T) {
 							Name:    "USER_ID",
 							Columns: []string{"USER_ID"},
 							Unique:  false,
+							Method:  "btree",
 						},
 					},
 				},
@@ -206,11 +208,13 @@ func TestAdapter_GetTable(t *testing.T) {
 							FromColumn: "TARGET_USER_ID",
 							ToTable:    "USERS",
 							ToColumn:   "ID",
+							Name:       "FK_FOLLOWERS_TARGET_USER_ID",
 						},
 						{
 							FromColumn: "USER_ID",
 							ToTable:    "USERS",
 							ToColumn:   "ID",
+							Name:       "FK_FOLLOWERS_USER_ID",
 						},
 					},
 					Indexes: []*db.Index{
@@ -218,11 +222,13 @@ func TestAdapter_GetTable(t *testing.T) {
 							Name:    "USER_ID_TARGET_USER_ID",
 							Columns: []string{"USER_ID", "TARGET_USER_ID"},
 							Unique:  true,
+							Method:  "btree",
 						},
 						{
 							Name:    "TARGET_USER_ID_USER_ID",
 							Columns: []string{"TARGET_USER_ID", "USER_ID"},
 							Unique:  true,
+							Method:  "btree",
 						},
 					},
 				},
@@ -240,3 +246,30 @@ func TestAdapter_GetTable(t *testing.T) {
 		}
 	})
 }
+
+func TestAdapter_GetColumnValues(t *testing.T) {
+	withDatabase(func(a *Adapter) {
+		// GROUP and ORDER are reserved words, so these can be used only with quotes
+		a.db.MustExec(`
+			CREATE TABLE "GROUP" (
+				id      integer not null primary key,
+				"ORDER" varchar2(191)
+		)`)
+		defer func() {
+			a.db.MustExec(`DROP TABLE "GROUP"`)
+		}()
+		a.db.MustExec(`INSERT INTO "GROUP" (id, "ORDER") VALUES (1, 'second')`)
+		a.db.MustExec(`INSERT INTO "GROUP" (id, "ORDER") VALUES (2, 'first')`)
+		a.db.MustExec(`INSERT INTO "GROUP" (id, "ORDER") VALUES (3, NULL)`)
+
+		got, err := a.GetColumnValues("group", "order", 10)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"first", "second"}, got)
+		}
+
+		got, err = a.GetColumnValues("GROUP", "Order", 1)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"first"}, got)
+		}
+	})
+}
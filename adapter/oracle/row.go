@@ -3,6 +3,7 @@ package oracle
 import (
 	"database/sql"
 	"fmt"
+	"strings"
 )
 
 type allTables struct {
@@ -10,12 +11,13 @@ type allTables struct {
 }
 
 type allTabColumns struct {
-	ColumnName    string        `db:"COLUMN_NAME"`
-	DataType      string        `db:"DATA_TYPE"`
-	DataLength    int           `db:"DATA_LENGTH"`
-	DataPrecision sql.NullInt32 `db:"DATA_PRECISION"`
-	DataScale     sql.NullInt32 `db:"DATA_SCALE"`
-	Nullable      string        `db:"NULLABLE"`
+	ColumnName    string         `db:"COLUMN_NAME"`
+	DataType      string         `db:"DATA_TYPE"`
+	DataLength    int            `db:"DATA_LENGTH"`
+	DataPrecision sql.NullInt32  `db:"DATA_PRECISION"`
+	DataScale     sql.NullInt32  `db:"DATA_SCALE"`
+	Nullable      string         `db:"NULLABLE"`
+	Comments      sql.NullString `db:"COMMENTS"`
 }
 
 func (c *allTabColumns) FormatColumnType() string {
@@ -46,13 +48,58 @@ type foreignKey struct {
 	ToTable          string `db:"TO_TABLE"`
 	ReferencesColumn string `db:"REFERENCES_COLUMN"`
 	ColumnName       string `db:"COLUMN_NAME"`
+	ConstraintName   string `db:"CONSTRAINT_NAME"`
 }
 
 type allIndexes struct {
 	IndexName  string `db:"INDEX_NAME"`
 	Uniqueness string `db:"UNIQUENESS"`
+	IndexType  string `db:"INDEX_TYPE"`
+}
+
+func (i *allIndexes) FormatIndexMethod() string {
+	switch i.IndexType {
+	case "NORMAL", "NORMAL/REV", "FUNCTION-BASED NORMAL":
+		return "btree"
+	case "BITMAP", "FUNCTION-BASED BITMAP":
+		return "bitmap"
+	}
+
+	return strings.ToLower(i.IndexType)
 }
 
 type allIndColumns struct {
-	ColumnName string `db:"COLUMN_NAME"`
+	ColumnName     string `db:"COLUMN_NAME"`
+	ColumnPosition int    `db:"COLUMN_POSITION"`
+	Descend        string `db:"DESCEND"`
+}
+
+type allIndExpressions struct {
+	ColumnExpression string `db:"COLUMN_EXPRESSION"`
+	ColumnPosition   int    `db:"COLUMN_POSITION"`
+}
+
+type allPartTables struct {
+	PartitioningType string `db:"PARTITIONING_TYPE"`
+	PartitionColumns string `db:"PARTITION_COLUMNS"`
+}
+
+type allTableStats struct {
+	NumRows   int64 `db:"NUM_ROWS"`
+	TableSize int64 `db:"TABLE_SIZE"`
+}
+
+type allTriggers struct {
+	TriggerName     string `db:"TRIGGER_NAME"`
+	TriggerType     string `db:"TRIGGER_TYPE"`
+	TriggeringEvent string `db:"TRIGGERING_EVENT"`
+	TriggerBody     string `db:"TRIGGER_BODY"`
+}
+
+func (t *allTriggers) Timing() string {
+	timing := t.TriggerType
+	for _, suffix := range []string{" EACH ROW", " STATEMENT", " EVENT"} {
+		timing = strings.TrimSuffix(timing, suffix)
+	}
+	return timing
 }
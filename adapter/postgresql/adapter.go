@@ -7,13 +7,15 @@ import (
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq" // for sql
 	"github.com/sue445/plant_erd/db"
+	"strconv"
 	"strings"
 )
 
 // Adapter represents PostgreSQL adapter
 type Adapter struct {
-	db     *sqlx.DB
-	dbName string
+	db            *sqlx.DB
+	dbName        string
+	serverVersion int
 }
 
 // Close represents function for close database
@@ -27,13 +29,31 @@ func NewAdapter(config *Config) (*Adapter, Close, error) {
 		return nil, nil, errors.WithStack(err)
 	}
 
-	return &Adapter{db: db, dbName: config.DBName}, db.Close, nil
+	var serverVersion string
+	err = db.Get(&serverVersion, "SHOW server_version_num")
+
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	version, _ := strconv.Atoi(serverVersion)
+
+	return &Adapter{db: db, dbName: config.DBName, serverVersion: version}, db.Close, nil
 }
 
 // GetAllTableNames returns all table names in database
 func (a *Adapter) GetAllTableNames() ([]string, error) {
 	var rows []pgStatUserTables
-	err := a.db.Select(&rows, "SELECT schemaname, relname FROM pg_stat_user_tables ORDER BY schemaname, relname")
+	// NOTE: partitioned tables (relkind = 'p') aren't contained in pg_stat_user_tables on some versions
+	err := a.db.Select(&rows, `
+		SELECT schemaname, relname FROM pg_stat_user_tables
+		UNION
+		SELECT n.nspname AS schemaname, c.relname
+		FROM pg_class c
+		JOIN pg_namespace n ON c.relnamespace = n.oid
+		WHERE c.relkind = 'p'
+		ORDER BY schemaname, relname
+	`)
 
 	if err != nil {
 		return []string{}, errors.WithStack(err)
@@ -62,15 +82,25 @@ func (a *Adapter) GetTable(tableWithSchemaName string) (*db.Table, error) {
 		return nil, errors.WithStack(err)
 	}
 
-	var rows []informationSchemaColumns
+	var rows []columnDefinitions
+
+	// c.f. column_definitions in https://github.com/rails/rails/blob/v6.0.1/activerecord/lib/active_record/connection_adapters/postgresql/schema_statements.rb
 	err = a.db.Select(&rows, `
-		SELECT column_name,
-		       data_type,
-		       is_nullable
-		FROM information_schema.columns
-		WHERE table_catalog = $1 AND table_name = $2 AND table_schema = $3
-		ORDER BY ordinal_position
-	`, a.dbName, tableName, schemaName)
+		SELECT a.attname AS column_name,
+		       format_type(a.atttypid, a.atttypmod) AS data_type,
+		       a.attnotnull AS not_null,
+		       COALESCE(pg_get_expr(d.adbin, d.adrelid), '') AS column_default,
+		       COALESCE(col_description(a.attrelid, a.attnum), '') AS comment
+		FROM pg_attribute a
+		JOIN pg_class c ON a.attrelid = c.oid
+		JOIN pg_namespace n ON c.relnamespace = n.oid
+		LEFT JOIN pg_attrdef d ON a.attrelid = d.adrelid AND a.attnum = d.adnum
+		WHERE c.relname = $1
+		  AND n.nspname = $2
+		  AND a.attnum > 0
+		  AND NOT a.attisdropped
+		ORDER BY a.attnum
+	`, tableName, schemaName)
 
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -80,8 +110,10 @@ func (a *Adapter) GetTable(tableWithSchemaName string) (*db.Table, error) {
 		column := &db.Column{
 			Name:       row.ColumnName,
 			Type:       row.DataType,
-			NotNull:    row.IsNullable == "NO",
+			NotNull:    row.NotNull,
 			PrimaryKey: primaryKeyColumns.Contains(row.ColumnName),
+			Default:    row.ColumnDefault,
+			Comment:    row.Comment,
 		}
 		table.Columns = append(table.Columns, column)
 	}
@@ -98,9 +130,126 @@ func (a *Adapter) GetTable(tableWithSchemaName string) (*db.Table, error) {
 	}
 	table.Indexes = indexes
 
+	triggers, err := a.getTriggers(tableName, schemaName)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	table.Triggers = triggers
+
+	err = a.loadPartition(&table, tableName, schemaName)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
 	return &table, nil
 }
 
+func (a *Adapter) getTriggers(tableName string, schemaName string) ([]*db.Trigger, error) {
+	var rows []pgTrigger
+	err := a.db.Select(&rows, `
+		SELECT t.tgname, t.tgtype, p.oid::regproc::text AS function_name, p.prosrc
+		FROM pg_trigger t
+		JOIN pg_class c ON t.tgrelid = c.oid
+		JOIN pg_namespace n ON c.relnamespace = n.oid
+		JOIN pg_proc p ON t.tgfoid = p.oid
+		WHERE NOT t.tgisinternal
+		  AND c.relname = $1
+		  AND n.nspname = $2
+		ORDER BY t.tgname
+	`, tableName, schemaName)
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var triggers []*db.Trigger
+	for _, row := range rows {
+		trigger := &db.Trigger{
+			Name:     row.Tgname,
+			Event:    row.Event(),
+			Timing:   row.Timing(),
+			Function: row.FunctionName,
+			Body:     row.Prosrc,
+		}
+		triggers = append(triggers, trigger)
+	}
+
+	return triggers, nil
+}
+
+// GetTableStats returns estimated row count and size of table
+func (a *Adapter) GetTableStats(tableWithSchemaName string) (*db.TableStats, error) {
+	names := strings.Split(tableWithSchemaName, ".")
+	schemaName := names[0]
+	tableName := names[1]
+
+	var row tableStats
+
+	// NOTE: reltuples is -1 when table has never been vacuumed or analyzed (PostgreSQL 14+)
+	err := a.db.Get(&row, `
+		SELECT GREATEST(c.reltuples, 0)::bigint AS rows,
+		       pg_total_relation_size(c.oid) AS size
+		FROM pg_class c
+		JOIN pg_namespace n ON c.relnamespace = n.oid
+		WHERE c.relname = $1
+		  AND n.nspname = $2
+	`, tableName, schemaName)
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &db.TableStats{Rows: row.Rows, Size: row.Size}, nil
+}
+
+// GetColumnValues returns up to limit distinct values of column
+func (a *Adapter) GetColumnValues(tableWithSchemaName string, columnName string, limit int) ([]string, error) {
+	names := strings.Split(tableWithSchemaName, ".")
+	schemaName := names[0]
+	tableName := names[1]
+
+	var values []string
+
+	sql := fmt.Sprintf(`SELECT DISTINCT "%s"::text FROM "%s"."%s" WHERE "%s" IS NOT NULL ORDER BY 1 LIMIT %d`, columnName, schemaName, tableName, columnName, limit)
+	err := a.db.Select(&values, sql)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return values, nil
+}
+
+func (a *Adapter) loadPartition(table *db.Table, tableName string, schemaName string) error {
+	// Declarative partitioning is available since PostgreSQL 10
+	if a.serverVersion < 100000 {
+		return nil
+	}
+
+	var rows []partition
+	err := a.db.Select(&rows, `
+		SELECT COALESCE(pg_get_partkeydef(c.oid), '') AS partition_key,
+		       COALESCE(pn.nspname || '.' || p.relname, '') AS partition_of
+		FROM pg_class c
+		JOIN pg_namespace n ON c.relnamespace = n.oid
+		LEFT JOIN pg_inherits i ON c.relispartition AND i.inhrelid = c.oid
+		LEFT JOIN pg_class p ON i.inhparent = p.oid
+		LEFT JOIN pg_namespace pn ON p.relnamespace = pn.oid
+		WHERE c.relname = $1
+		  AND n.nspname = $2
+	`, tableName, schemaName)
+
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, row := range rows {
+		table.PartitionKey = row.PartitionKey
+		table.PartitionOf = row.PartitionOf
+	}
+
+	return nil
+}
+
 func (a *Adapter) getPrimaryKeyColumns(tableName string) (mapset.Set[string], error) {
 	var rows []primaryKeys
 
@@ -157,6 +306,7 @@ func (a *Adapter) getForeignKeys(tableName string, schemaName string) ([]*db.For
 			FromColumn: row.Column,
 			ToTable:    row.ToTable,
 			ToColumn:   row.PrimaryKey,
+			Name:       row.Name,
 		}
 
 		// Add public schema
@@ -174,10 +324,12 @@ func (a *Adapter) getIndexes(tableName string, schemaName string) ([]*db.Index,
 	// c.f. https://github.com/rails/rails/blob/v6.0.1/activerecord/lib/active_record/connection_adapters/postgresql/schema_statements.rb#L89
 	var rows []indexes
 	err := a.db.Select(&rows, `
-		SELECT distinct i.relname, d.indisunique, d.indkey, t.oid
+		SELECT distinct i.relname, d.indisunique, d.indkey, d.indoption, t.oid, d.indexrelid, am.amname,
+		       COALESCE(pg_get_expr(d.indpred, t.oid), '') AS indpred
 		FROM pg_class t
 		INNER JOIN pg_index d ON t.oid = d.indrelid
 		INNER JOIN pg_class i ON d.indexrelid = i.oid
+		INNER JOIN pg_am am ON i.relam = am.oid
 		LEFT JOIN pg_namespace n ON n.oid = i.relnamespace
 		WHERE i.relkind = 'i'
 		  AND d.indisprimary = 'f'
@@ -192,49 +344,69 @@ func (a *Adapter) getIndexes(tableName string, schemaName string) ([]*db.Index,
 
 	var indexes []*db.Index
 	for _, row := range rows {
-		columns, err := a.getIndexColumns(row.Oid, row.Indkeys())
+		index := &db.Index{
+			Name:      row.Relname,
+			Unique:    row.Indisunique,
+			Method:    row.Amname,
+			Predicate: row.Indpred,
+		}
+
+		err := a.loadIndexColumns(index, row)
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
 
-		index := &db.Index{
-			Name:    row.Relname,
-			Unique:  row.Indisunique,
-			Columns: columns,
-		}
 		indexes = append(indexes, index)
 	}
 
 	return indexes, nil
 }
 
-func (a *Adapter) getIndexColumns(oid int, indkeys []int) ([]string, error) {
+func (a *Adapter) loadIndexColumns(index *db.Index, row indexes) error {
+	indkeys := row.Indkeys()
+	indoptions := row.Indoptions()
+
 	// c.f. https://github.com/rails/rails/blob/v6.0.1/activerecord/lib/active_record/connection_adapters/postgresql/schema_statements.rb#L119
 	sql := "SELECT a.attnum AS attnum, a.attname AS attname FROM pg_attribute a WHERE a.attrelid = ? AND a.attnum IN (?)"
 
-	query, args, err := sqlx.In(sql, oid, indkeys)
+	query, args, err := sqlx.In(sql, row.Oid, indkeys)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return errors.WithStack(err)
 	}
 
 	query = a.db.Rebind(query)
 
-	var rows []pgAttribute
-	err = a.db.Select(&rows, query, args...)
+	var attributes []pgAttribute
+	err = a.db.Select(&attributes, query, args...)
 
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return errors.WithStack(err)
 	}
 
 	columnNames := map[int]string{}
-	for _, row := range rows {
-		columnNames[row.Attnum] = row.Attname
+	for _, attribute := range attributes {
+		columnNames[attribute.Attnum] = attribute.Attname
 	}
 
-	var columns []string
-	for _, indkey := range indkeys {
-		columns = append(columns, columnNames[int(indkey)])
+	for i, indkey := range indkeys {
+		if indkey == 0 {
+			// attnum is 0 when key is an expression
+			var expression string
+			err := a.db.Get(&expression, "SELECT pg_get_indexdef($1, $2, true)", row.Indexrelid, i+1)
+			if err != nil {
+				return errors.WithStack(err)
+			}
+
+			index.AddExpression(expression)
+		} else {
+			index.AddColumn(columnNames[indkey])
+		}
+
+		// c.f. INDOPTION_DESC in https://github.com/postgres/postgres/blob/REL_12_STABLE/src/include/catalog/pg_index.h
+		if i < len(indoptions) && indoptions[i]&1 != 0 {
+			index.SetOrder("DESC")
+		}
 	}
 
-	return columns, nil
+	return nil
 }
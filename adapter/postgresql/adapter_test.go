@@ -34,6 +34,9 @@ func withDatabase(callback func(*Adapter)) {
 
 	defer closeDatabase() //nolint:errcheck
 
+	adapter.db.MustExec("DROP TABLE IF EXISTS audit_logs;")
+	adapter.db.MustExec("DROP TABLE IF EXISTS events;")
+	adapter.db.MustExec("DROP TABLE IF EXISTS products;")
 	adapter.db.MustExec("DROP TABLE IF EXISTS followers;")
 	adapter.db.MustExec("DROP TABLE IF EXISTS articles;")
 	adapter.db.MustExec("DROP TABLE IF EXISTS users;")
@@ -162,6 +165,21 @@ func TestAdapter_GetTable_in_public_schema(t *testing.T) {
 		a.db.MustExec("CREATE UNIQUE INDEX index_user_id_and_target_user_id_on_followers ON followers(user_id, target_user_id)")
 		a.db.MustExec("CREATE UNIQUE INDEX index_target_user_id_and_user_id_on_followers ON followers(target_user_id, user_id)")
 
+		a.db.MustExec(`
+			CREATE TABLE products (
+				id    integer not null primary key,
+				name  varchar(255) not null default 'unnamed',
+				price numeric(12,2),
+				tags  text[]
+		);`)
+		a.db.MustExec("COMMENT ON COLUMN products.price IS 'Price in JPY'")
+		defer func() {
+			a.db.MustExec("DROP TABLE products;")
+		}()
+		a.db.MustExec("CREATE INDEX index_lower_name_on_products ON products(lower(name)) WHERE price IS NOT NULL")
+		a.db.MustExec("CREATE INDEX index_price_on_products ON products(price DESC)")
+		a.db.MustExec("CREATE INDEX index_tags_on_products ON products USING gin(tags)")
+
 		type args struct {
 			tableName string
 		}
@@ -216,6 +234,7 @@ func TestAdapter_GetTable_in_public_schema(t *testing.T) {
 							FromColumn: "user_id",
 							ToTable:    "public.users",
 							ToColumn:   "id",
+							Name:       "articles_user_id_fkey",
 						},
 					},
 					Indexes: []*db.Index{
@@ -223,6 +242,7 @@ func TestAdapter_GetTable_in_public_schema(t *testing.T) {
 							Name:    "index_user_id_on_articles",
 							Columns: []string{"user_id"},
 							Unique:  false,
+							Method:  "btree",
 						},
 					},
 				},
@@ -257,11 +277,13 @@ func TestAdapter_GetTable_in_public_schema(t *testing.T) {
 							FromColumn: "target_user_id",
 							ToTable:    "public.users",
 							ToColumn:   "id",
+							Name:       "followers_target_user_id_fkey",
 						},
 						{
 							FromColumn: "user_id",
 							ToTable:    "public.users",
 							ToColumn:   "id",
+							Name:       "followers_user_id_fkey",
 						},
 					},
 					Indexes: []*db.Index{
@@ -269,11 +291,64 @@ func TestAdapter_GetTable_in_public_schema(t *testing.T) {
 							Name:    "index_target_user_id_and_user_id_on_followers",
 							Columns: []string{"target_user_id", "user_id"},
 							Unique:  true,
+							Method:  "btree",
 						},
 						{
 							Name:    "index_user_id_and_target_user_id_on_followers",
 							Columns: []string{"user_id", "target_user_id"},
 							Unique:  true,
+							Method:  "btree",
+						},
+					},
+				},
+			},
+			{
+				name: "public.products",
+				args: args{
+					tableName: "public.products",
+				},
+				want: &db.Table{
+					Name: "public.products",
+					Columns: []*db.Column{
+						{
+							Name:       "id",
+							Type:       "integer",
+							NotNull:    true,
+							PrimaryKey: true,
+						},
+						{
+							Name:    "name",
+							Type:    "character varying(255)",
+							NotNull: true,
+							Default: "'unnamed'::character varying",
+						},
+						{
+							Name:    "price",
+							Type:    "numeric(12,2)",
+							Comment: "Price in JPY",
+						},
+						{
+							Name: "tags",
+							Type: "text[]",
+						},
+					},
+					Indexes: []*db.Index{
+						{
+							Name:      "index_lower_name_on_products",
+							Method:    "btree",
+							KeyParts:  []*db.IndexKeyPart{{Expression: "lower(name::text)"}},
+							Predicate: "(price IS NOT NULL)",
+						},
+						{
+							Name:    "index_price_on_products",
+							Columns: []string{"price"},
+							Method:  "btree",
+							Orders:  []string{"DESC"},
+						},
+						{
+							Name:    "index_tags_on_products",
+							Columns: []string{"tags"},
+							Method:  "gin",
 						},
 					},
 				},
@@ -394,6 +469,7 @@ func TestAdapter_GetTable_in_non_public_schema(t *testing.T) {
 							FromColumn: "author_id",
 							ToTable:    "people.author",
 							ToColumn:   "id",
+							Name:       "book_author_fk_1",
 						},
 					},
 				},
@@ -433,3 +509,139 @@ func TestAdapter_GetTable_in_non_public_schema(t *testing.T) {
 		}
 	})
 }
+
+func TestAdapter_GetTable_with_partitioned_table(t *testing.T) {
+	withDatabase(func(a *Adapter) {
+		if a.serverVersion < 100000 {
+			t.Skip("Declarative partitioning requires PostgreSQL 10+")
+		}
+
+		a.db.MustExec(`
+			CREATE TABLE events (
+				id         integer not null,
+				created_at date not null
+			) PARTITION BY RANGE (created_at);`)
+		defer func() {
+			a.db.MustExec("DROP TABLE events;")
+		}()
+
+		a.db.MustExec(`
+			CREATE TABLE events_2024_01 PARTITION OF events
+				FOR VALUES FROM ('2024-01-01') TO ('2024-02-01');`)
+
+		gotTables, err := a.GetAllTableNames()
+		if assert.NoError(t, err) {
+			assert.Contains(t, gotTables, "public.events")
+			assert.Contains(t, gotTables, "public.events_2024_01")
+		}
+
+		type args struct {
+			tableName string
+		}
+		tests := []struct {
+			name string
+			args args
+			want *db.Table
+		}{
+			{
+				name: "public.events",
+				args: args{
+					tableName: "public.events",
+				},
+				want: &db.Table{
+					Name: "public.events",
+					Columns: []*db.Column{
+						{
+							Name:    "id",
+							Type:    "integer",
+							NotNull: true,
+						},
+						{
+							Name:    "created_at",
+							Type:    "date",
+							NotNull: true,
+						},
+					},
+					PartitionKey: "RANGE (created_at)",
+				},
+			},
+			{
+				name: "public.events_2024_01",
+				args: args{
+					tableName: "public.events_2024_01",
+				},
+				want: &db.Table{
+					Name: "public.events_2024_01",
+					Columns: []*db.Column{
+						{
+							Name:    "id",
+							Type:    "integer",
+							NotNull: true,
+						},
+						{
+							Name:    "created_at",
+							Type:    "date",
+							NotNull: true,
+						},
+					},
+					PartitionOf: "public.events",
+				},
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				got, err := a.GetTable(tt.args.tableName)
+
+				if assert.NoError(t, err) {
+					assert.Equal(t, tt.want, got)
+				}
+			})
+		}
+	})
+}
+
+func TestAdapter_GetTable_with_trigger(t *testing.T) {
+	withDatabase(func(a *Adapter) {
+		a.db.MustExec(`
+			CREATE TABLE users (
+				id   integer not null primary key,
+				name text
+		);`)
+		defer func() {
+			a.db.MustExec("DROP TABLE users;")
+		}()
+
+		a.db.MustExec(`
+			CREATE TABLE audit_logs (
+				user_id integer not null
+		);`)
+		defer func() {
+			a.db.MustExec("DROP TABLE audit_logs;")
+		}()
+
+		a.db.MustExec(`
+			CREATE OR REPLACE FUNCTION users_audit() RETURNS trigger AS $$
+			BEGIN
+				INSERT INTO audit_logs (user_id) VALUES (NEW.id);
+				RETURN NEW;
+			END;
+			$$ LANGUAGE plpgsql;`)
+		defer func() {
+			a.db.MustExec("DROP FUNCTION users_audit() CASCADE;")
+		}()
+
+		a.db.MustExec("CREATE TRIGGER users_audit_trigger AFTER INSERT OR UPDATE ON users FOR EACH ROW EXECUTE PROCEDURE users_audit();")
+
+		got, err := a.GetTable("public.users")
+
+		if assert.NoError(t, err) && assert.Len(t, got.Triggers, 1) {
+			trigger := got.Triggers[0]
+			assert.Equal(t, "users_audit_trigger", trigger.Name)
+			assert.Equal(t, "INSERT OR UPDATE", trigger.Event)
+			assert.Equal(t, "AFTER", trigger.Timing)
+			assert.Equal(t, "users_audit", trigger.Function)
+			assert.Contains(t, trigger.Body, "INSERT INTO audit_logs")
+		}
+	})
+}
@@ -10,10 +10,12 @@ type pgStatUserTables struct {
 	Schemaname string `db:"schemaname"`
 }
 
-type informationSchemaColumns struct {
-	ColumnName string `db:"column_name"`
-	DataType   string `db:"data_type"`
-	IsNullable string `db:"is_nullable"`
+type columnDefinitions struct {
+	ColumnName    string `db:"column_name"`
+	DataType      string `db:"data_type"`
+	NotNull       bool   `db:"not_null"`
+	ColumnDefault string `db:"column_default"`
+	Comment       string `db:"comment"`
 }
 
 type primaryKeys struct {
@@ -31,22 +33,95 @@ type indexes struct {
 	Relname     string `db:"relname"`
 	Indisunique bool   `db:"indisunique"`
 	Indkey      string `db:"indkey"`
+	Indoption   string `db:"indoption"`
 	Oid         int    `db:"oid"`
+	Indexrelid  int    `db:"indexrelid"`
+	Amname      string `db:"amname"`
+	Indpred     string `db:"indpred"`
 }
 
 func (i *indexes) Indkeys() []int {
-	keys := strings.Split(i.Indkey, " ")
+	return splitInt2Vector(i.Indkey)
+}
+
+func (i *indexes) Indoptions() []int {
+	return splitInt2Vector(i.Indoption)
+}
 
-	indkeys := []int{}
+func splitInt2Vector(str string) []int {
+	keys := strings.Split(str, " ")
+
+	values := []int{}
 	for _, key := range keys {
 		i, _ := strconv.Atoi(key)
-		indkeys = append(indkeys, i)
+		values = append(values, i)
 	}
 
-	return indkeys
+	return values
 }
 
 type pgAttribute struct {
 	Attnum  int    `db:"attnum"`
 	Attname string `db:"attname"`
 }
+
+type partition struct {
+	PartitionKey string `db:"partition_key"`
+	PartitionOf  string `db:"partition_of"`
+}
+
+type tableStats struct {
+	Rows int64 `db:"rows"`
+	Size int64 `db:"size"`
+}
+
+// c.f. TRIGGER_TYPE_* in https://github.com/postgres/postgres/blob/REL_12_STABLE/src/include/catalog/pg_trigger.h
+const (
+	triggerTypeBefore   = 1 << 1
+	triggerTypeInsert   = 1 << 2
+	triggerTypeDelete   = 1 << 3
+	triggerTypeUpdate   = 1 << 4
+	triggerTypeTruncate = 1 << 5
+	triggerTypeInstead  = 1 << 6
+)
+
+type pgTrigger struct {
+	Tgname       string `db:"tgname"`
+	Tgtype       int    `db:"tgtype"`
+	FunctionName string `db:"function_name"`
+	Prosrc       string `db:"prosrc"`
+}
+
+func (t *pgTrigger) Event() string {
+	var events []string
+
+	if t.Tgtype&triggerTypeInsert != 0 {
+		events = append(events, "INSERT")
+	}
+
+	if t.Tgtype&triggerTypeUpdate != 0 {
+		events = append(events, "UPDATE")
+	}
+
+	if t.Tgtype&triggerTypeDelete != 0 {
+		events = append(events, "DELETE")
+	}
+
+	if t.Tgtype&triggerTypeTruncate != 0 {
+		events = append(events, "TRUNCATE")
+	}
+
+	return strings.Join(events, " OR ")
+}
+
+func (t *pgTrigger) Timing() string {
+	if t.Tgtype&triggerTypeInstead != 0 {
+		return "INSTEAD OF"
+	}
+
+	if t.Tgtype&triggerTypeBefore != 0 {
+		return "BEFORE"
+	}
+
+	return "AFTER"
+}
@@ -0,0 +1,226 @@
+package prisma
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sue445/plant_erd/db"
+)
+
+const schemaPrisma = `
+datasource db {
+  provider = "postgresql"
+  url      = env("DATABASE_URL")
+}
+
+generator client {
+  provider = "prisma-client-js"
+}
+
+enum Status {
+  DRAFT
+  PUBLISHED
+}
+
+// users of service
+model User {
+  id        Int       @id @default(autoincrement())
+  email     String    @unique @db.VarChar(255)
+  name      String?
+  createdAt DateTime  @default(now()) @map("created_at")
+  posts     Post[]
+  tags      String[]
+
+  @@map("users")
+}
+
+model Post {
+  id        String   @id @default(dbgenerated("gen_random_uuid()")) @db.Uuid
+  title     String   @default("untitled") // comment with "quote"
+  status    Status   @default(DRAFT)
+  authorId  Int      @map("author_id")
+  author    User     @relation(fields: [authorId], references: [id], onDelete: Cascade)
+  createdAt DateTime @default(now())
+
+  @@index([authorId, createdAt(sort: Desc)])
+  @@index([title], map: "posts_title_hash", type: Hash)
+  @@map("posts")
+}
+
+model PostTag {
+  postId String
+  tag    String
+  post   Post   @relation(fields: [postId], references: [id], map: "post_tag_post_fk")
+
+  @@id([postId, tag])
+  @@unique([tag, postId])
+}
+`
+
+func newTestAdapter(t *testing.T) *Adapter {
+	filename := filepath.Join(t.TempDir(), "schema.prisma")
+	err := os.WriteFile(filename, []byte(schemaPrisma), 0644)
+	require.NoError(t, err)
+
+	adapter, err := NewAdapter(filename)
+	require.NoError(t, err)
+
+	return adapter
+}
+
+func TestAdapter_GetAllTableNames(t *testing.T) {
+	a := newTestAdapter(t)
+
+	tables, err := a.GetAllTableNames()
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"PostTag", "posts", "users"}, tables)
+	}
+}
+
+func TestAdapter_GetTable(t *testing.T) {
+	a := newTestAdapter(t)
+
+	type args struct {
+		tableName string
+	}
+	tests := []struct {
+		name string
+		args args
+		want *db.Table
+	}{
+		{
+			name: "users",
+			args: args{
+				tableName: "users",
+			},
+			want: &db.Table{
+				Name: "users",
+				Columns: []*db.Column{
+					{Name: "id", Type: "Int", NotNull: true, PrimaryKey: true, Default: "autoincrement()"},
+					{Name: "email", Type: "VarChar(255)", NotNull: true},
+					{Name: "name", Type: "String"},
+					{Name: "created_at", Type: "DateTime", NotNull: true, Default: "now()"},
+					{Name: "tags", Type: "String[]"},
+				},
+				Indexes: []*db.Index{
+					{Name: "users_email_key", Columns: []string{"email"}, Unique: true},
+				},
+			},
+		},
+		{
+			name: "posts",
+			args: args{
+				tableName: "posts",
+			},
+			want: &db.Table{
+				Name: "posts",
+				Columns: []*db.Column{
+					{Name: "id", Type: "Uuid", NotNull: true, PrimaryKey: true, Default: "gen_random_uuid()"},
+					{Name: "title", Type: "String", NotNull: true, Default: "'untitled'"},
+					{Name: "status", Type: "Status", NotNull: true, Default: "DRAFT"},
+					{Name: "author_id", Type: "Int", NotNull: true},
+					{Name: "createdAt", Type: "DateTime", NotNull: true, Default: "now()"},
+				},
+				ForeignKeys: []*db.ForeignKey{
+					{FromColumn: "author_id", ToTable: "users", ToColumn: "id"},
+				},
+				Indexes: []*db.Index{
+					{
+						Name:    "posts_author_id_createdAt_idx",
+						Columns: []string{"author_id", "createdAt"},
+						Orders:  []string{"", "DESC"},
+					},
+					{
+						Name:    "posts_title_hash",
+						Columns: []string{"title"},
+						Method:  "hash",
+					},
+				},
+			},
+		},
+		{
+			name: "PostTag",
+			args: args{
+				tableName: "PostTag",
+			},
+			want: &db.Table{
+				Name: "PostTag",
+				Columns: []*db.Column{
+					{Name: "postId", Type: "String", NotNull: true, PrimaryKey: true},
+					{Name: "tag", Type: "String", NotNull: true, PrimaryKey: true},
+				},
+				ForeignKeys: []*db.ForeignKey{
+					{FromColumn: "postId", ToTable: "posts", ToColumn: "id", Name: "post_tag_post_fk"},
+				},
+				Indexes: []*db.Index{
+					{Name: "PostTag_tag_postId_key", Columns: []string{"tag", "postId"}, Unique: true},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := a.GetTable(tt.args.tableName)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func Test_parseSchema_Error(t *testing.T) {
+	_, err := parseSchema("model User {\n  id Int @default(autoincrement()\n}\n")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2: unclosed attribute")
+}
+
+func TestAdapter_withPascalCaseModel(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "schema.prisma")
+	err := os.WriteFile(filename, []byte(`
+model User {
+  tenantId Int
+  id       Int
+  posts    Post[]
+
+  @@id(fields: [tenantId, id], name: "tenant_user_id")
+}
+
+model Post {
+  id       Int  @id
+  authorId Int
+  author   User @relation(fields: [authorId], references: [id])
+}
+`), 0644)
+	require.NoError(t, err)
+
+	a, err := NewAdapter(filename)
+	require.NoError(t, err)
+
+	tableNames, err := a.GetAllTableNames()
+	require.NoError(t, err)
+
+	var tables []*db.Table
+	for _, tableName := range tableNames {
+		table, err := a.GetTable(tableName)
+		require.NoError(t, err)
+		tables = append(tables, table)
+	}
+	schema := db.NewSchema(tables)
+
+	user := tables[1]
+	if assert.Equal(t, "User", user.Name) {
+		assert.Equal(t, []*db.Column{
+			{Name: "tenantId", Type: "Int", NotNull: true, PrimaryKey: true},
+			{Name: "id", Type: "Int", NotNull: true, PrimaryKey: true},
+		}, user.Columns)
+	}
+
+	assert.Contains(t, schema.ToErd(db.DiagramOption{}), "Post }-- User")
+	assert.Contains(t, schema.ToMermaid(db.DiagramOption{}), "User ||--o{ Post : owns")
+}
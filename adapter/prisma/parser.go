@@ -0,0 +1,542 @@
+package prisma
+
+import (
+	"fmt"
+	"github.com/sue445/plant_erd/db"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	blockRe = regexp.MustCompile(`^(model|enum|type|view|datasource|generator)\s+(\w+)\s*\{$`)
+	fieldRe = regexp.MustCompile(`^(\w+)\s+([\w.]+(?:\([^)]*\))?)(\[\])?(\?)?\s*(.*)$`)
+)
+
+type attribute struct {
+	name string
+	args string
+}
+
+type field struct {
+	name       string
+	fieldType  string
+	isList     bool
+	optional   bool
+	attributes []*attribute
+
+	// comment represents documentation comment (`///`) of field
+	comment string
+}
+
+type model struct {
+	name            string
+	fields          []*field
+	blockAttributes []*attribute
+}
+
+// parseSchema parses schema.prisma and returns tables
+func parseSchema(content string) (map[string]*db.Table, error) {
+	var models []*model
+
+	var current *model
+	inBlock := false
+	var comments []string
+
+	for i, line := range strings.Split(content, "\n") {
+		if doc, ok := strings.CutPrefix(strings.TrimSpace(line), "///"); ok {
+			comments = append(comments, strings.TrimSpace(doc))
+			continue
+		}
+
+		line = strings.TrimSpace(stripComment(line))
+
+		if line == "" {
+			continue
+		}
+
+		if inBlock {
+			if line == "}" {
+				inBlock = false
+				current = nil
+				continue
+			}
+
+			if current == nil {
+				continue
+			}
+
+			if strings.HasPrefix(line, "@@") {
+				comments = nil
+
+				// e.g. @@index([userId]) -> @index([userId])
+				attributes, err := parseAttributes(line[1:])
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", i+1, err)
+				}
+				current.blockAttributes = append(current.blockAttributes, attributes...)
+				continue
+			}
+
+			matched := fieldRe.FindStringSubmatch(line)
+			if matched == nil {
+				return nil, fmt.Errorf("line %d: unexpected field definition: %s", i+1, line)
+			}
+
+			attributes, err := parseAttributes(matched[5])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+
+			current.fields = append(current.fields, &field{
+				name:       matched[1],
+				fieldType:  matched[2],
+				isList:     matched[3] != "",
+				optional:   matched[4] != "",
+				attributes: attributes,
+				comment:    strings.Join(comments, "\n"),
+			})
+			comments = nil
+			continue
+		}
+
+		if matched := blockRe.FindStringSubmatch(line); matched != nil {
+			inBlock = true
+			comments = nil
+
+			// enum, type, datasource and generator blocks are skipped
+			if matched[1] == "model" || matched[1] == "view" {
+				current = &model{name: matched[2]}
+				models = append(models, current)
+			}
+		}
+	}
+
+	return buildTables(models), nil
+}
+
+// stripComment removes `//` comment which isn't in string literal
+func stripComment(line string) string {
+	inString := false
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && inString:
+			i++
+		case line[i] == '"':
+			inString = !inString
+		case !inString && strings.HasPrefix(line[i:], "//"):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// parseAttributes parses attributes (e.g. `@id @default(autoincrement()) @db.VarChar(255)`)
+func parseAttributes(str string) ([]*attribute, error) {
+	var attributes []*attribute
+
+	str = strings.TrimSpace(str)
+	for str != "" {
+		if str[0] != '@' {
+			return nil, fmt.Errorf("unexpected attribute: %s", str)
+		}
+
+		end := 1
+		for end < len(str) && (isIdentChar(str[end]) || str[end] == '.') {
+			end++
+		}
+
+		attr := &attribute{name: str[1:end]}
+		str = str[end:]
+
+		if strings.HasPrefix(str, "(") {
+			closing := findClosingParen(str)
+			if closing < 0 {
+				return nil, fmt.Errorf("unclosed attribute: @%s%s", attr.name, str)
+			}
+
+			attr.args = strings.TrimSpace(str[1:closing])
+			str = str[closing+1:]
+		}
+
+		attributes = append(attributes, attr)
+		str = strings.TrimSpace(str)
+	}
+
+	return attributes, nil
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// findClosingParen returns index of paren which closes str[0]
+func findClosingParen(str string) int {
+	depth := 0
+	inString := false
+
+	for i := 0; i < len(str); i++ {
+		switch {
+		case inString && str[i] == '\\':
+			i++
+		case str[i] == '"':
+			inString = !inString
+		case inString:
+			continue
+		case str[i] == '(':
+			depth++
+		case str[i] == ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// parseArgs splits attribute arguments into positional arguments and named arguments
+func parseArgs(str string) ([]string, map[string]string) {
+	var args []string
+	named := map[string]string{}
+
+	for _, part := range splitTopLevel(str) {
+		key, value, found := strings.Cut(part, ":")
+		if found && isIdentifier(strings.TrimSpace(key)) {
+			named[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			continue
+		}
+
+		args = append(args, part)
+	}
+
+	return args, named
+}
+
+func isIdentifier(str string) bool {
+	if str == "" {
+		return false
+	}
+
+	for i := 0; i < len(str); i++ {
+		if !isIdentChar(str[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// splitTopLevel splits str with comma which isn't in quotes or brackets
+func splitTopLevel(str string) []string {
+	var parts []string
+
+	depth := 0
+	inString := false
+	start := 0
+
+	for i := 0; i < len(str); i++ {
+		switch {
+		case inString && str[i] == '\\':
+			i++
+		case str[i] == '"':
+			inString = !inString
+		case inString:
+			continue
+		case str[i] == '[' || str[i] == '(' || str[i] == '{':
+			depth++
+		case str[i] == ']' || str[i] == ')' || str[i] == '}':
+			depth--
+		case str[i] == ',' && depth == 0:
+			parts = append(parts, strings.TrimSpace(str[start:i]))
+			start = i + 1
+		}
+	}
+
+	if last := strings.TrimSpace(str[start:]); last != "" {
+		parts = append(parts, last)
+	}
+
+	return parts
+}
+
+// unquote returns value of string literal. This returns str as it is when str isn't string literal
+func unquote(str string) string {
+	unquoted, err := strconv.Unquote(strings.TrimSpace(str))
+	if err != nil {
+		return strings.TrimSpace(str)
+	}
+	return unquoted
+}
+
+// parseFieldList parses field list (e.g. `[userId, createdAt(sort: Desc)]`)
+func parseFieldList(str string) []*attribute {
+	str = strings.TrimSpace(str)
+	str = strings.TrimPrefix(str, "[")
+	str = strings.TrimSuffix(str, "]")
+
+	var fields []*attribute
+	for _, part := range splitTopLevel(str) {
+		name, args, _ := strings.Cut(part, "(")
+		fields = append(fields, &attribute{name: strings.TrimSpace(name), args: strings.TrimSuffix(args, ")")})
+	}
+	return fields
+}
+
+// fieldList returns fields of block attribute (e.g. @@id([a, b]), @@id(fields: [a, b]))
+func (a *attribute) fieldList() []*attribute {
+	args, named := parseArgs(a.args)
+	if fields, ok := named["fields"]; ok {
+		return parseFieldList(fields)
+	}
+	if len(args) > 0 {
+		return parseFieldList(args[0])
+	}
+	return nil
+}
+
+func findAttribute(attributes []*attribute, name string) *attribute {
+	for _, attr := range attributes {
+		if attr.name == name {
+			return attr
+		}
+	}
+	return nil
+}
+
+func (m *model) tableName() string {
+	if attr := findAttribute(m.blockAttributes, "map"); attr != nil {
+		args, named := parseArgs(attr.args)
+		if name, ok := named["name"]; ok {
+			return unquote(name)
+		}
+		if len(args) > 0 {
+			return unquote(args[0])
+		}
+	}
+	return m.name
+}
+
+func (f *field) columnName() string {
+	if attr := findAttribute(f.attributes, "map"); attr != nil {
+		args, named := parseArgs(attr.args)
+		if name, ok := named["name"]; ok {
+			return unquote(name)
+		}
+		if len(args) > 0 {
+			return unquote(args[0])
+		}
+	}
+	return f.name
+}
+
+func (m *model) findField(name string) *field {
+	for _, f := range m.fields {
+		if f.name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// columnName returns column name of field. This returns field name as it is when field isn't found
+func (m *model) columnName(fieldName string) string {
+	if f := m.findField(fieldName); f != nil {
+		return f.columnName()
+	}
+	return fieldName
+}
+
+func buildTables(models []*model) map[string]*db.Table {
+	modelsByName := map[string]*model{}
+	for _, m := range models {
+		modelsByName[m.name] = m
+	}
+
+	tables := map[string]*db.Table{}
+	for _, m := range models {
+		table := &db.Table{Name: m.tableName()}
+
+		for _, f := range m.fields {
+			if _, ok := modelsByName[f.fieldType]; ok {
+				// Relation field
+				continue
+			}
+
+			table.Columns = append(table.Columns, buildColumn(f))
+
+			if attr := findAttribute(f.attributes, "unique"); attr != nil {
+				index := buildIndex(m, table, []*attribute{{name: f.name}}, attr.args, "key")
+				index.Unique = true
+				table.Indexes = append(table.Indexes, index)
+			}
+		}
+
+		for _, attr := range m.blockAttributes {
+			fields := attr.fieldList()
+
+			switch attr.name {
+			case "id":
+				for _, pkField := range fields {
+					for _, column := range table.Columns {
+						if column.Name == m.columnName(pkField.name) {
+							column.PrimaryKey = true
+						}
+					}
+				}
+
+			case "unique", "index":
+				if len(fields) == 0 {
+					continue
+				}
+
+				suffix := "idx"
+				if attr.name == "unique" {
+					suffix = "key"
+				}
+
+				index := buildIndex(m, table, fields, attr.args, suffix)
+				index.Unique = attr.name == "unique"
+				table.Indexes = append(table.Indexes, index)
+			}
+		}
+
+		for _, f := range m.fields {
+			target, ok := modelsByName[f.fieldType]
+			if !ok {
+				continue
+			}
+
+			if foreignKey := buildForeignKey(m, target, f); foreignKey != nil {
+				table.ForeignKeys = append(table.ForeignKeys, foreignKey)
+			}
+		}
+
+		tables[table.Name] = table
+	}
+
+	return tables
+}
+
+func buildColumn(f *field) *db.Column {
+	column := &db.Column{
+		Name:       f.columnName(),
+		Type:       f.fieldType,
+		NotNull:    !f.optional && !f.isList,
+		PrimaryKey: findAttribute(f.attributes, "id") != nil,
+		Comment:    f.comment,
+	}
+
+	for _, attr := range f.attributes {
+		if strings.HasPrefix(attr.name, "db.") {
+			// Native database type (e.g. @db.VarChar(255))
+			column.Type = strings.TrimPrefix(attr.name, "db.")
+			if attr.args != "" {
+				column.Type += fmt.Sprintf("(%s)", attr.args)
+			}
+		}
+	}
+
+	if f.isList {
+		column.Type += "[]"
+	}
+
+	if attr := findAttribute(f.attributes, "default"); attr != nil {
+		args, _ := parseArgs(attr.args)
+		if len(args) > 0 {
+			column.Default = formatDefault(args[0])
+		}
+	}
+
+	return column
+}
+
+// formatDefault converts default value to SQL-like expression (e.g. "draft" -> 'draft', dbgenerated("gen_random_uuid()") -> gen_random_uuid())
+func formatDefault(value string) string {
+	if strings.HasPrefix(value, "dbgenerated(") {
+		args, _ := parseArgs(strings.TrimSuffix(strings.TrimPrefix(value, "dbgenerated("), ")"))
+		if len(args) > 0 {
+			return unquote(args[0])
+		}
+		return ""
+	}
+
+	if strings.HasPrefix(value, `"`) {
+		return fmt.Sprintf("'%s'", unquote(value))
+	}
+
+	return value
+}
+
+// buildIndex returns index. Index name is same as Prisma default constraint name unless map argument is specified
+func buildIndex(m *model, table *db.Table, fields []*attribute, argsStr string, suffix string) *db.Index {
+	_, named := parseArgs(argsStr)
+
+	index := &db.Index{}
+
+	for _, f := range fields {
+		index.AddColumn(m.columnName(f.name))
+
+		_, options := parseArgs(f.args)
+		if sort, ok := options["sort"]; ok && strings.EqualFold(sort, "Desc") {
+			index.SetOrder("DESC")
+		}
+
+		if length, ok := options["length"]; ok {
+			if n, err := strconv.Atoi(length); err == nil {
+				index.SetLength(n)
+			}
+		}
+	}
+
+	if name, ok := named["map"]; ok {
+		index.Name = unquote(name)
+	} else {
+		index.Name = fmt.Sprintf("%s_%s_%s", table.Name, strings.Join(index.Columns, "_"), suffix)
+	}
+
+	if indexType, ok := named["type"]; ok {
+		// e.g. type: Hash
+		index.Method = strings.ToLower(indexType)
+	}
+
+	return index
+}
+
+// buildForeignKey returns foreign key of relation field (e.g. `author User @relation(fields: [authorId], references: [id])`)
+func buildForeignKey(m *model, target *model, f *field) *db.ForeignKey {
+	attr := findAttribute(f.attributes, "relation")
+	if attr == nil {
+		return nil
+	}
+
+	_, named := parseArgs(attr.args)
+
+	fields, ok := named["fields"]
+	if !ok {
+		return nil
+	}
+
+	references, ok := named["references"]
+	if !ok {
+		return nil
+	}
+
+	fromFields := parseFieldList(fields)
+	toFields := parseFieldList(references)
+	if len(fromFields) == 0 || len(toFields) == 0 {
+		return nil
+	}
+
+	foreignKey := &db.ForeignKey{
+		FromColumn: m.columnName(fromFields[0].name),
+		ToTable:    target.tableName(),
+		ToColumn:   target.columnName(toFields[0].name),
+	}
+
+	if name, ok := named["map"]; ok {
+		foreignKey.Name = unquote(name)
+	}
+
+	return foreignKey
+}
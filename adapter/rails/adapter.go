@@ -0,0 +1,54 @@
+package rails
+
+import (
+	"fmt"
+	"github.com/cockroachdb/errors"
+	"github.com/sue445/plant_erd/db"
+	"os"
+	"sort"
+)
+
+// Adapter represents Rails schema.rb adapter
+type Adapter struct {
+	tables map[string]*db.Table
+}
+
+// NewAdapter returns a new Adapter instance
+func NewAdapter(filename string) (*Adapter, error) {
+	content, err := os.ReadFile(filename)
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	tables, err := parseSchema(string(content))
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &Adapter{tables: tables}, nil
+}
+
+// GetAllTableNames returns all table names in schema.rb
+func (a *Adapter) GetAllTableNames() ([]string, error) {
+	var tables []string
+	for tableName := range a.tables {
+		tables = append(tables, tableName)
+	}
+
+	sort.Strings(tables)
+
+	return tables, nil
+}
+
+// GetTable returns table info
+func (a *Adapter) GetTable(tableName string) (*db.Table, error) {
+	table, ok := a.tables[tableName]
+
+	if !ok {
+		return nil, fmt.Errorf("%s is not found in schema.rb", tableName)
+	}
+
+	return table, nil
+}
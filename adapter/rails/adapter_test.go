@@ -0,0 +1,191 @@
+package rails
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sue445/plant_erd/db"
+)
+
+const schemaRb = `
+# This file is auto-generated from the current state of the database.
+
+ActiveRecord::Schema[7.1].define(version: 2024_01_01_000000) do
+  # These are extensions that must be enabled in order to support this database
+  enable_extension "plpgsql"
+
+  create_enum "article_status", ["draft", "published"]
+
+  create_table "articles", force: :cascade do |t|
+    t.bigint "user_id", null: false
+    t.string "title", limit: 255, null: false
+    t.text "body"
+    t.decimal "price", precision: 12, scale: 2
+    t.string "tags", default: [], array: true
+    t.enum "status", default: "draft", null: false, enum_type: "article_status"
+    t.datetime "published_at", default: -> { "CURRENT_TIMESTAMP" }
+    t.timestamps
+    t.index ["user_id", "published_at"], name: "index_articles_on_user_id_and_published_at", order: { published_at: :desc }
+    t.index "lower((title)::text)", name: "index_articles_on_lower_title"
+    t.index ["title"], unique: true, where: "(body IS NOT NULL)"
+  end
+
+  create_table "tags", id: false, force: :cascade do |t|
+    t.string "name", null: false
+  end
+
+  create_table "users", id: :uuid, force: :cascade do |t|
+    t.string "name"
+    t.integer "age", default: 0
+  end
+
+  create_table "user_tags", primary_key: ["user_id", "tag_name"], force: :cascade do |t|
+    t.uuid "user_id", null: false
+    t.string "tag_name", null: false
+  end
+
+  add_foreign_key "articles", "users"
+  add_foreign_key "user_tags", "tags", column: "tag_name", primary_key: "name", name: "fk_user_tags_tag_name"
+  add_foreign_key "user_tags", "users"
+end
+`
+
+func newTestAdapter(t *testing.T) *Adapter {
+	filename := filepath.Join(t.TempDir(), "schema.rb")
+	err := os.WriteFile(filename, []byte(schemaRb), 0644)
+	require.NoError(t, err)
+
+	adapter, err := NewAdapter(filename)
+	require.NoError(t, err)
+
+	return adapter
+}
+
+func TestAdapter_GetAllTableNames(t *testing.T) {
+	a := newTestAdapter(t)
+
+	tables, err := a.GetAllTableNames()
+
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"articles", "tags", "user_tags", "users"}, tables)
+	}
+}
+
+func TestAdapter_GetTable(t *testing.T) {
+	a := newTestAdapter(t)
+
+	type args struct {
+		tableName string
+	}
+	tests := []struct {
+		name string
+		args args
+		want *db.Table
+	}{
+		{
+			name: "articles",
+			args: args{
+				tableName: "articles",
+			},
+			want: &db.Table{
+				Name: "articles",
+				Columns: []*db.Column{
+					{Name: "id", Type: "bigint", NotNull: true, PrimaryKey: true},
+					{Name: "user_id", Type: "bigint", NotNull: true},
+					{Name: "title", Type: "string(255)", NotNull: true},
+					{Name: "body", Type: "text"},
+					{Name: "price", Type: "decimal(12,2)"},
+					{Name: "tags", Type: "string[]", Default: "[]"},
+					{Name: "status", Type: "article_status", NotNull: true, Default: "'draft'"},
+					{Name: "published_at", Type: "datetime", Default: "CURRENT_TIMESTAMP"},
+					{Name: "created_at", Type: "datetime", NotNull: true},
+					{Name: "updated_at", Type: "datetime", NotNull: true},
+				},
+				ForeignKeys: []*db.ForeignKey{
+					{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+				},
+				Indexes: []*db.Index{
+					{
+						Name:    "index_articles_on_user_id_and_published_at",
+						Columns: []string{"user_id", "published_at"},
+						Orders:  []string{"", "DESC"},
+					},
+					{
+						Name:     "index_articles_on_lower_title",
+						KeyParts: []*db.IndexKeyPart{{Expression: "lower((title)::text)"}},
+					},
+					{
+						Name:      "index_articles_on_title",
+						Columns:   []string{"title"},
+						Unique:    true,
+						Predicate: "(body IS NOT NULL)",
+					},
+				},
+			},
+		},
+		{
+			name: "tags",
+			args: args{
+				tableName: "tags",
+			},
+			want: &db.Table{
+				Name: "tags",
+				Columns: []*db.Column{
+					{Name: "name", Type: "string", NotNull: true},
+				},
+			},
+		},
+		{
+			name: "users",
+			args: args{
+				tableName: "users",
+			},
+			want: &db.Table{
+				Name: "users",
+				Columns: []*db.Column{
+					{Name: "id", Type: "uuid", NotNull: true, PrimaryKey: true},
+					{Name: "name", Type: "string"},
+					{Name: "age", Type: "integer", Default: "0"},
+				},
+			},
+		},
+		{
+			name: "user_tags",
+			args: args{
+				tableName: "user_tags",
+			},
+			want: &db.Table{
+				Name: "user_tags",
+				Columns: []*db.Column{
+					{Name: "user_id", Type: "uuid", NotNull: true, PrimaryKey: true},
+					{Name: "tag_name", Type: "string", NotNull: true, PrimaryKey: true},
+				},
+				ForeignKeys: []*db.ForeignKey{
+					{FromColumn: "tag_name", ToTable: "tags", ToColumn: "name", Name: "fk_user_tags_tag_name"},
+					{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := a.GetTable(tt.args.tableName)
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAdapter_GetTable_NotFound(t *testing.T) {
+	a := newTestAdapter(t)
+
+	_, err := a.GetTable("unknown")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown is not found in schema.rb")
+}
@@ -0,0 +1,437 @@
+package rails
+
+import (
+	"fmt"
+	"github.com/sue445/plant_erd/db"
+	"regexp"
+	"strings"
+)
+
+var (
+	createTableRe   = regexp.MustCompile(`^create_table\s+(.+?)\s+do\s*\|\w+\|$`)
+	tableMethodRe   = regexp.MustCompile(`^\w+\.(\w+)(?:\s+(.*))?$`)
+	statementRe     = regexp.MustCompile(`^(add_foreign_key|add_index)\s+(.*)$`)
+	hashKeyRe       = regexp.MustCompile(`^(?::?(\w+):|:(\w+)\s*=>|"(\w+)"\s*=>)\s*(.*)$`)
+	lambdaDefaultRe = regexp.MustCompile(`^->\s*\{\s*(.*?)\s*\}$`)
+)
+
+// defaultPrimaryKeyType represents type of primary key when `id` option isn't specified (Rails 5.1+)
+const defaultPrimaryKeyType = "bigint"
+
+type foreignKeyStatement struct {
+	fromTable  string
+	foreignKey *db.ForeignKey
+}
+
+// parseSchema parses schema.rb and returns tables
+func parseSchema(content string) (map[string]*db.Table, error) {
+	tables := map[string]*db.Table{}
+	var foreignKeys []*foreignKeyStatement
+	var indexes []*indexStatement
+
+	var current *db.Table
+
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if current != nil {
+			if line == "end" {
+				current = nil
+				continue
+			}
+
+			err := parseTableMethod(current, line)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+			continue
+		}
+
+		if matched := createTableRe.FindStringSubmatch(line); matched != nil {
+			table, err := parseCreateTable(matched[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", i+1, err)
+			}
+
+			tables[table.Name] = table
+			current = table
+			continue
+		}
+
+		if matched := statementRe.FindStringSubmatch(line); matched != nil {
+			args, options := parseArgs(matched[2])
+			if len(args) < 2 {
+				return nil, fmt.Errorf("line %d: %s requires 2 arguments", i+1, matched[1])
+			}
+
+			switch matched[1] {
+			case "add_foreign_key":
+				foreignKeys = append(foreignKeys, parseAddForeignKey(args, options))
+			case "add_index":
+				indexes = append(indexes, &indexStatement{tableName: rubyString(args[0]), index: parseIndex(rubyString(args[0]), args[1], options)})
+			}
+		}
+	}
+
+	for _, statement := range foreignKeys {
+		if table, ok := tables[statement.fromTable]; ok {
+			table.ForeignKeys = append(table.ForeignKeys, statement.foreignKey)
+		}
+	}
+
+	for _, statement := range indexes {
+		if table, ok := tables[statement.tableName]; ok {
+			table.Indexes = append(table.Indexes, statement.index)
+		}
+	}
+
+	return tables, nil
+}
+
+type indexStatement struct {
+	tableName string
+	index     *db.Index
+}
+
+// parseCreateTable parses arguments of `create_table "users", id: :serial, force: :cascade`
+func parseCreateTable(argsStr string) (*db.Table, error) {
+	args, options := parseArgs(argsStr)
+
+	if len(args) < 1 {
+		return nil, fmt.Errorf("create_table requires table name")
+	}
+
+	table := &db.Table{Name: rubyString(args[0])}
+
+	if primaryKey, ok := options["primary_key"]; ok && strings.HasPrefix(primaryKey, "[") {
+		// Composite primary key columns are defined in block
+		for _, columnName := range rubyArray(primaryKey) {
+			table.Columns = append(table.Columns, &db.Column{Name: columnName, PrimaryKey: true})
+		}
+		return table, nil
+	}
+
+	id, hasID := options["id"]
+	if hasID && id == "false" {
+		return table, nil
+	}
+
+	column := &db.Column{
+		Name:       "id",
+		Type:       defaultPrimaryKeyType,
+		NotNull:    true,
+		PrimaryKey: true,
+	}
+
+	if primaryKey, ok := options["primary_key"]; ok {
+		column.Name = rubyString(primaryKey)
+	}
+
+	if hasID {
+		if strings.HasPrefix(id, "{") {
+			// e.g. id: { type: :string, limit: 36 }
+			idOptions := rubyHash(id)
+			if idType, ok := idOptions["type"]; ok {
+				column.Type = formatColumnType(rubyString(idType), idOptions)
+			}
+		} else {
+			column.Type = rubyString(id)
+		}
+	}
+
+	table.Columns = append(table.Columns, column)
+
+	return table, nil
+}
+
+// parseTableMethod parses method call in create_table block (e.g. `t.string "name", null: false`)
+func parseTableMethod(table *db.Table, line string) error {
+	matched := tableMethodRe.FindStringSubmatch(line)
+	if matched == nil {
+		return nil
+	}
+
+	method := matched[1]
+	args, options := parseArgs(matched[2])
+
+	switch method {
+	case "index":
+		if len(args) < 1 {
+			return fmt.Errorf("t.index requires columns")
+		}
+		table.Indexes = append(table.Indexes, parseIndex(table.Name, args[0], options))
+		return nil
+
+	case "timestamps":
+		for _, name := range []string{"created_at", "updated_at"} {
+			addColumn(table, &db.Column{Name: name, Type: "datetime", NotNull: options["null"] != "true"})
+		}
+		return nil
+
+	case "column":
+		// e.g. t.column "name", :string
+		if len(args) < 2 {
+			return fmt.Errorf("t.column requires name and type")
+		}
+		method = rubyString(args[1])
+		args = args[:1]
+
+	case "check_constraint", "exclusion_constraint", "unique_constraint", "references", "belongs_to":
+		// Not supported
+		return nil
+	}
+
+	if len(args) < 1 {
+		return fmt.Errorf("t.%s requires column name", method)
+	}
+
+	columnType := method
+	if enumType, ok := options["enum_type"]; ok {
+		columnType = rubyString(enumType)
+	}
+
+	for _, arg := range args {
+		column := &db.Column{
+			Name:    rubyString(arg),
+			Type:    formatColumnType(columnType, options),
+			NotNull: options["null"] == "false",
+			Default: formatDefault(options["default"]),
+			Comment: rubyString(options["comment"]),
+		}
+		addColumn(table, column)
+	}
+
+	return nil
+}
+
+func addColumn(table *db.Table, column *db.Column) {
+	// Primary key column may be already declared with create_table options
+	for _, existing := range table.Columns {
+		if existing.Name == column.Name {
+			existing.Type = column.Type
+			existing.NotNull = existing.NotNull || column.NotNull
+			existing.Default = column.Default
+			existing.Comment = column.Comment
+			return
+		}
+	}
+
+	table.Columns = append(table.Columns, column)
+}
+
+// parseIndex parses arguments of `t.index ["user_id"], name: "index_articles_on_user_id"`
+func parseIndex(tableName string, columns string, options map[string]string) *db.Index {
+	index := &db.Index{
+		Name:      rubyString(options["name"]),
+		Unique:    options["unique"] == "true",
+		Method:    rubyString(options["using"]),
+		Predicate: rubyString(options["where"]),
+	}
+
+	if strings.HasPrefix(columns, "[") {
+		// e.g. order: { published_at: :desc }, length: { name: 10 }
+		orders := rubyHash(options["order"])
+		lengths := rubyHash(options["length"])
+
+		for _, columnName := range rubyArray(columns) {
+			index.AddColumn(columnName)
+
+			if value, ok := orders[columnName]; ok {
+				index.SetOrder(strings.ToUpper(rubyString(value)))
+			}
+
+			var length int
+			if _, err := fmt.Sscanf(lengths[columnName], "%d", &length); err == nil {
+				index.SetLength(length)
+			}
+		}
+	} else {
+		// e.g. t.index "lower((name)::text)", name: "index_users_on_lower_name"
+		index.AddExpression(rubyString(columns))
+	}
+
+	if index.Name == "" {
+		index.Name = fmt.Sprintf("index_%s_on_%s", tableName, strings.Join(index.Columns, "_and_"))
+	}
+
+	return index
+}
+
+// parseAddForeignKey parses arguments of `add_foreign_key "articles", "users", column: "author_id"`
+func parseAddForeignKey(args []string, options map[string]string) *foreignKeyStatement {
+	toTable := rubyString(args[1])
+
+	foreignKey := &db.ForeignKey{
+		FromColumn: db.Singularize(toTable) + "_id",
+		ToTable:    toTable,
+		ToColumn:   "id",
+	}
+
+	if column, ok := options["column"]; ok {
+		foreignKey.FromColumn = firstOf(column)
+	}
+
+	if primaryKey, ok := options["primary_key"]; ok {
+		foreignKey.ToColumn = firstOf(primaryKey)
+	}
+
+	if name, ok := options["name"]; ok {
+		foreignKey.Name = rubyString(name)
+	}
+
+	return &foreignKeyStatement{fromTable: rubyString(args[0]), foreignKey: foreignKey}
+}
+
+// firstOf returns first element when value is an array (composite foreign key)
+func firstOf(value string) string {
+	if strings.HasPrefix(value, "[") {
+		values := rubyArray(value)
+		if len(values) > 0 {
+			return values[0]
+		}
+		return ""
+	}
+	return rubyString(value)
+}
+
+func formatColumnType(columnType string, options map[string]string) string {
+	if precision, ok := options["precision"]; ok {
+		if scale, ok := options["scale"]; ok {
+			columnType += fmt.Sprintf("(%s,%s)", precision, scale)
+		} else {
+			columnType += fmt.Sprintf("(%s)", precision)
+		}
+	} else if limit, ok := options["limit"]; ok {
+		columnType += fmt.Sprintf("(%s)", limit)
+	}
+
+	if options["array"] == "true" {
+		columnType += "[]"
+	}
+
+	return columnType
+}
+
+func formatDefault(value string) string {
+	if value == "" || value == "nil" {
+		return ""
+	}
+
+	// e.g. default: -> { "now()" }
+	if matched := lambdaDefaultRe.FindStringSubmatch(value); matched != nil {
+		return rubyString(matched[1])
+	}
+
+	if isRubyString(value) {
+		return fmt.Sprintf("'%s'", rubyString(value))
+	}
+
+	return rubyString(value)
+}
+
+// parseArgs splits ruby method arguments into positional arguments and keyword options
+func parseArgs(str string) ([]string, map[string]string) {
+	var args []string
+	options := map[string]string{}
+
+	for _, part := range splitTopLevel(str) {
+		if matched := hashKeyRe.FindStringSubmatch(part); matched != nil {
+			key := matched[1] + matched[2] + matched[3]
+			options[key] = strings.TrimSpace(matched[4])
+			continue
+		}
+
+		args = append(args, part)
+	}
+
+	return args, options
+}
+
+// splitTopLevel splits str with comma which isn't in quotes or brackets
+func splitTopLevel(str string) []string {
+	var parts []string
+
+	depth := 0
+	var quote rune
+	escaped := false
+	start := 0
+
+	for i, c := range str {
+		if quote != 0 {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == quote:
+				quote = 0
+			}
+			continue
+		}
+
+		switch c {
+		case '"', '\'':
+			quote = c
+		case '[', '{', '(':
+			depth++
+		case ']', '}', ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(str[start:i]))
+				start = i + 1
+			}
+		}
+	}
+
+	if last := strings.TrimSpace(str[start:]); last != "" {
+		parts = append(parts, last)
+	}
+
+	return parts
+}
+
+func isRubyString(value string) bool {
+	return len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0]
+}
+
+// rubyString returns string value of ruby string literal or symbol (e.g. "users" -> users, :cascade -> cascade)
+func rubyString(value string) string {
+	value = strings.TrimSpace(value)
+
+	if isRubyString(value) {
+		unquoted := value[1 : len(value)-1]
+		unquoted = strings.ReplaceAll(unquoted, `\"`, `"`)
+		unquoted = strings.ReplaceAll(unquoted, `\\`, `\`)
+		return unquoted
+	}
+
+	return strings.TrimPrefix(value, ":")
+}
+
+// rubyArray returns values of ruby array literal (e.g. ["user_id", "created_at"])
+func rubyArray(value string) []string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(value, "[")
+	value = strings.TrimSuffix(value, "]")
+
+	var values []string
+	for _, part := range splitTopLevel(value) {
+		values = append(values, rubyString(part))
+	}
+	return values
+}
+
+// rubyHash returns values of ruby hash literal (e.g. { created_at: :desc })
+func rubyHash(value string) map[string]string {
+	value = strings.TrimSpace(value)
+	value = strings.TrimPrefix(value, "{")
+	value = strings.TrimSuffix(value, "}")
+
+	_, options := parseArgs(value)
+	return options
+}
@@ -1,11 +1,14 @@
 package sqlite3
 
 import (
+	"database/sql"
 	"fmt"
 	"github.com/cockroachdb/errors"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/mattn/go-sqlite3" // for sql
 	"github.com/sue445/plant_erd/db"
+	"regexp"
+	"strings"
 )
 
 // Adapter represents sqlite3 adapter
@@ -31,6 +34,15 @@ func toBool(i int64) bool {
 	return i != 0
 }
 
+// toDefault returns default value of PRAGMA table_info. Explicit `DEFAULT NULL` is same as no default value
+func toDefault(value interface{}) string {
+	str, ok := value.(string)
+	if !ok || strings.EqualFold(str, "NULL") {
+		return ""
+	}
+	return str
+}
+
 // GetAllTableNames returns all table names in database
 func (a *Adapter) GetAllTableNames() ([]string, error) {
 	var rows []sqliteMaster
@@ -73,6 +85,7 @@ func (a *Adapter) GetTable(tableName string) (*db.Table, error) {
 			Type:       row["type"].(string),
 			NotNull:    toBool(row["notnull"].(int64)),
 			PrimaryKey: toBool(row["pk"].(int64)),
+			Default:    toDefault(row["dflt_value"]),
 		}
 
 		table.Columns = append(table.Columns, column)
@@ -92,9 +105,48 @@ func (a *Adapter) GetTable(tableName string) (*db.Table, error) {
 
 	table.Indexes = indexes
 
+	triggers, err := a.getTriggers(tableName)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	table.Triggers = triggers
+
 	return &table, nil
 }
 
+// GetTableStats returns row count and size of table
+func (a *Adapter) GetTableStats(tableName string) (*db.TableStats, error) {
+	stats := &db.TableStats{}
+
+	err := a.DB.Get(&stats.Rows, fmt.Sprintf("SELECT COUNT(*) FROM %s", quoteIdentifier(tableName)))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// NOTE: dbstat is available only when SQLite is compiled with SQLITE_ENABLE_DBSTAT_VTAB
+	err = a.DB.Get(&stats.Size, "SELECT COALESCE(SUM(pgsize), 0) FROM dbstat WHERE name = ?", tableName)
+	if err != nil && !strings.Contains(err.Error(), "no such table: dbstat") {
+		return nil, errors.WithStack(err)
+	}
+
+	return stats, nil
+}
+
+// GetColumnValues returns up to limit distinct values of column
+func (a *Adapter) GetColumnValues(tableName string, columnName string, limit int) ([]string, error) {
+	var values []string
+
+	column := quoteIdentifier(columnName)
+	sql := fmt.Sprintf("SELECT DISTINCT CAST(%s AS TEXT) FROM %s WHERE %s IS NOT NULL ORDER BY 1 LIMIT %d", column, quoteIdentifier(tableName), column, limit)
+	err := a.DB.Select(&values, sql)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return values, nil
+}
+
 func (a *Adapter) getForeignKeys(tableName string) ([]*db.ForeignKey, error) {
 	rows, err := a.DB.Queryx(fmt.Sprintf("PRAGMA foreign_key_list(%s)", tableName))
 
@@ -153,38 +205,160 @@ func (a *Adapter) getIndexes(tableName string) ([]*db.Index, error) {
 			Unique: row["unique"].(int64) != 0,
 		}
 
-		columns, err := a.getIndexColumns(index.Name)
+		err = a.loadIndexColumns(index)
 
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
 
-		index.Columns = columns
-
 		indexes = append(indexes, index)
 	}
 
 	return indexes, nil
 }
 
-func (a *Adapter) getIndexColumns(indexName string) ([]string, error) {
-	rows, err := a.DB.Queryx(fmt.Sprintf("PRAGMA index_info(%s)", indexName))
+func (a *Adapter) loadIndexColumns(index *db.Index) error {
+	var indexSQL sql.NullString
+	err := a.DB.Get(&indexSQL, "SELECT sql FROM sqlite_master WHERE type = 'index' AND name = ?", index.Name)
+
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return errors.WithStack(err)
+	}
+
+	// NOTE: sql is NULL when index is created automatically (e.g. sqlite_autoindex_*)
+	keyParts, predicate := parseCreateIndexSQL(indexSQL.String)
+	index.Predicate = predicate
+
+	rows, err := a.DB.Queryx(fmt.Sprintf("PRAGMA index_xinfo(%s)", index.Name))
 
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return errors.WithStack(err)
 	}
 
-	var columns []string
 	for rows.Next() {
 		row := map[string]interface{}{}
 		err := rows.MapScan(row)
 
 		if err != nil {
-			return nil, errors.WithStack(err)
+			return errors.WithStack(err)
+		}
+
+		// auxiliary columns (e.g. rowid) aren't a part of key
+		if !toBool(row["key"].(int64)) {
+			continue
 		}
 
-		columns = append(columns, row["name"].(string))
+		// cid is -2 when key is an expression
+		if row["cid"].(int64) == -2 {
+			seqno := int(row["seqno"].(int64))
+			if seqno >= len(keyParts) {
+				continue
+			}
+			index.AddExpression(keyParts[seqno])
+		} else {
+			index.AddColumn(row["name"].(string))
+		}
+
+		if toBool(row["desc"].(int64)) {
+			index.SetOrder("DESC")
+		}
 	}
 
-	return columns, nil
+	return nil
+}
+
+var triggerRe = regexp.MustCompile(`(?is)^CREATE\s+(?:TEMP\s+|TEMPORARY\s+)?TRIGGER\s+(?:IF\s+NOT\s+EXISTS\s+)?\S+\s+(BEFORE\s+|AFTER\s+|INSTEAD\s+OF\s+)?(INSERT|DELETE|UPDATE)\b`)
+
+func (a *Adapter) getTriggers(tableName string) ([]*db.Trigger, error) {
+	var rows []sqliteMasterTrigger
+	err := a.DB.Select(&rows, "SELECT name, sql FROM sqlite_master WHERE type = 'trigger' AND tbl_name = ? ORDER BY name", tableName)
+
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var triggers []*db.Trigger
+	for _, row := range rows {
+		trigger := &db.Trigger{
+			Name: row.Name,
+			Body: row.SQL,
+		}
+
+		if matched := triggerRe.FindStringSubmatch(strings.TrimSpace(row.SQL)); matched != nil {
+			trigger.Timing = strings.ToUpper(strings.Join(strings.Fields(matched[1]), " "))
+			trigger.Event = strings.ToUpper(matched[2])
+
+			// BEFORE is default timing
+			if trigger.Timing == "" {
+				trigger.Timing = "BEFORE"
+			}
+		}
+
+		triggers = append(triggers, trigger)
+	}
+
+	return triggers, nil
+}
+
+var sortOrderRe = regexp.MustCompile(`(?i)\s+(ASC|DESC)$`)
+var whereRe = regexp.MustCompile(`(?is)^WHERE\s+(.+)$`)
+
+// parseCreateIndexSQL returns key parts and WHERE clause of CREATE INDEX statement
+func parseCreateIndexSQL(indexSQL string) ([]string, string) {
+	var keyParts []string
+	depth := 0
+	partStart := -1
+	end := -1
+
+	for i := 0; i < len(indexSQL) && end < 0; i++ {
+		switch indexSQL[i] {
+		case '\'', '"', '`', '[':
+			// Skip string literal and quoted identifier which may contain comma or parentheses
+			closing := indexSQL[i]
+			if closing == '[' {
+				closing = ']'
+			}
+			next := strings.IndexByte(indexSQL[i+1:], closing)
+			if next < 0 {
+				return nil, ""
+			}
+			i += next + 1
+		case '(':
+			depth++
+			if partStart < 0 {
+				partStart = i + 1
+			}
+		case ')':
+			depth--
+			if depth == 0 {
+				end = i
+			}
+		case ',':
+			if depth == 1 {
+				keyParts = append(keyParts, indexSQL[partStart:i])
+				partStart = i + 1
+			}
+		}
+	}
+
+	if end < 0 {
+		return nil, ""
+	}
+	keyParts = append(keyParts, indexSQL[partStart:end])
+
+	for i, keyPart := range keyParts {
+		keyParts[i] = sortOrderRe.ReplaceAllString(strings.TrimSpace(keyPart), "")
+	}
+
+	predicate := ""
+	if matched := whereRe.FindStringSubmatch(strings.TrimSpace(indexSQL[end+1:])); matched != nil {
+		predicate = strings.TrimSpace(matched[1])
+	}
+
+	return keyParts, predicate
+}
+
+// quoteIdentifier returns identifier which is quoted with double quotes
+func quoteIdentifier(identifier string) string {
+	return `"` + strings.ReplaceAll(identifier, `"`, `""`) + `"`
 }
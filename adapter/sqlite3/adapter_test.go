@@ -85,6 +85,15 @@ func TestAdapter_GetTable(t *testing.T) {
 					unique (album_id, genre_id)
 		);`)
 
+		a.DB.MustExec(`
+			CREATE TABLE products (
+				id    integer not null primary key,
+				name  text not null,
+				price integer default 0
+		);`)
+		a.DB.MustExec("CREATE INDEX index_lower_name_on_products ON products(lower(name)) WHERE price IS NOT NULL")
+		a.DB.MustExec("CREATE INDEX index_price_on_products ON products(price DESC)")
+
 		type args struct {
 			tableName string
 		}
@@ -241,6 +250,45 @@ func TestAdapter_GetTable(t *testing.T) {
 					},
 				},
 			},
+			{
+				name: "products",
+				args: args{
+					tableName: "products",
+				},
+				want: &db.Table{
+					Name: "products",
+					Columns: []*db.Column{
+						{
+							Name:       "id",
+							Type:       "INTEGER",
+							NotNull:    true,
+							PrimaryKey: true,
+						},
+						{
+							Name:    "name",
+							Type:    "TEXT",
+							NotNull: true,
+						},
+						{
+							Name:    "price",
+							Type:    "INTEGER",
+							Default: "0",
+						},
+					},
+					Indexes: []*db.Index{
+						{
+							Name:    "index_price_on_products",
+							Columns: []string{"price"},
+							Orders:  []string{"DESC"},
+						},
+						{
+							Name:      "index_lower_name_on_products",
+							KeyParts:  []*db.IndexKeyPart{{Expression: "lower(name)"}},
+							Predicate: "price IS NOT NULL",
+						},
+					},
+				},
+			},
 		}
 
 		for _, tt := range tests {
@@ -254,3 +302,148 @@ func TestAdapter_GetTable(t *testing.T) {
 		}
 	})
 }
+
+func Test_parseCreateIndexSQL(t *testing.T) {
+	tests := []struct {
+		name          string
+		indexSQL      string
+		wantKeyParts  []string
+		wantPredicate string
+	}{
+		{
+			name:         "simple index",
+			indexSQL:     "CREATE INDEX index_user_id_on_articles ON articles(user_id)",
+			wantKeyParts: []string{"user_id"},
+		},
+		{
+			name:          "expression and partial index",
+			indexSQL:      "CREATE UNIQUE INDEX index_on_users ON users (lower(name), substr(email, 1, 3) DESC) WHERE deleted_at IS NULL",
+			wantKeyParts:  []string{"lower(name)", "substr(email, 1, 3)"},
+			wantPredicate: "deleted_at IS NULL",
+		},
+		{
+			name:          "quoted literal and identifier",
+			indexSQL:      `CREATE INDEX "index_(a,b)" ON "order items" (coalesce(note, 'a,(b'), "x,y" ASC, [p)q]) WHERE note <> ')'`,
+			wantKeyParts:  []string{"coalesce(note, 'a,(b')", `"x,y"`, "[p)q]"},
+			wantPredicate: "note <> ')'",
+		},
+		{
+			name:     "empty",
+			indexSQL: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotKeyParts, gotPredicate := parseCreateIndexSQL(tt.indexSQL)
+			assert.Equal(t, tt.wantKeyParts, gotKeyParts)
+			assert.Equal(t, tt.wantPredicate, gotPredicate)
+		})
+	}
+}
+
+func TestAdapter_GetTableStats(t *testing.T) {
+	withDatabase(func(a *Adapter) {
+		a.DB.MustExec(`
+			CREATE TABLE users (
+				id   integer not null primary key,
+				name text
+		);`)
+		a.DB.MustExec("INSERT INTO users (id, name) VALUES (1, 'alice'), (2, 'bob')")
+
+		got, err := a.GetTableStats("users")
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(2), got.Rows)
+		}
+	})
+}
+
+func TestAdapter_GetTableStats_withQuotedName(t *testing.T) {
+	withDatabase(func(a *Adapter) {
+		a.DB.MustExec(`CREATE TABLE "order" (id integer not null primary key);`)
+		a.DB.MustExec(`INSERT INTO "order" (id) VALUES (1)`)
+
+		got, err := a.GetTableStats("order")
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, int64(1), got.Rows)
+		}
+	})
+}
+
+func TestAdapter_GetColumnValues(t *testing.T) {
+	withDatabase(func(a *Adapter) {
+		a.DB.MustExec(`
+			CREATE TABLE comments (
+				id               integer not null primary key,
+				commentable_type text,
+				commentable_id   integer
+		);`)
+		a.DB.MustExec(`
+			INSERT INTO comments (id, commentable_type, commentable_id)
+			VALUES (1, 'Photo', 1), (2, 'Article', 1), (3, 'Photo', 2), (4, NULL, NULL), (5, 'Video', 1)`)
+
+		got, err := a.GetColumnValues("comments", "commentable_type", 2)
+
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"Article", "Photo"}, got)
+		}
+	})
+}
+
+func TestAdapter_GetColumnValues_withQuotedName(t *testing.T) {
+	withDatabase(func(a *Adapter) {
+		a.DB.MustExec(`CREATE TABLE "group" ("order" text, "item""type" text);`)
+		a.DB.MustExec(`INSERT INTO "group" ("order", "item""type") VALUES ('first', 'Article')`)
+
+		got, err := a.GetColumnValues("group", "order", 10)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"first"}, got)
+		}
+
+		got, err = a.GetColumnValues("group", `item"type`, 10)
+		if assert.NoError(t, err) {
+			assert.Equal(t, []string{"Article"}, got)
+		}
+	})
+}
+
+func TestAdapter_GetTable_with_trigger(t *testing.T) {
+	withDatabase(func(a *Adapter) {
+		a.DB.MustExec(`
+			CREATE TABLE users (
+				id   integer not null primary key,
+				name text
+		);`)
+
+		a.DB.MustExec(`
+			CREATE TABLE audit_logs (
+				user_id integer not null
+		);`)
+
+		a.DB.MustExec(`
+			CREATE TRIGGER users_audit AFTER INSERT ON users
+			BEGIN
+				INSERT INTO audit_logs (user_id) VALUES (NEW.id);
+			END;`)
+
+		a.DB.MustExec(`
+			CREATE TRIGGER users_check UPDATE OF name ON users
+			BEGIN
+				SELECT RAISE(ABORT, 'name is required') WHERE NEW.name IS NULL;
+			END;`)
+
+		got, err := a.GetTable("users")
+
+		if assert.NoError(t, err) && assert.Len(t, got.Triggers, 2) {
+			assert.Equal(t, "users_audit", got.Triggers[0].Name)
+			assert.Equal(t, "INSERT", got.Triggers[0].Event)
+			assert.Equal(t, "AFTER", got.Triggers[0].Timing)
+			assert.Contains(t, got.Triggers[0].Body, "INSERT INTO audit_logs")
+
+			assert.Equal(t, "users_check", got.Triggers[1].Name)
+			assert.Equal(t, "UPDATE", got.Triggers[1].Event)
+			assert.Equal(t, "BEFORE", got.Triggers[1].Timing)
+		}
+	})
+}
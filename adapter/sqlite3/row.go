@@ -3,3 +3,8 @@ package sqlite3
 type sqliteMaster struct {
 	Name string `db:"name"`
 }
+
+type sqliteMasterTrigger struct {
+	Name string `db:"name"`
+	SQL  string `db:"sql"`
+}
@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"github.com/sue445/plant_erd/lib"
 	"github.com/urfave/cli/v3"
+	"strings"
 )
 
 // CreateCliCommonFlags returns common flags for cli
-func CreateCliCommonFlags(generator *lib.ErdGenerator) []cli.Flag {
+func CreateCliCommonFlags(generator *lib.ErdGenerator, loadOption *lib.LoadSchemaOption) []cli.Flag {
 	return []cli.Flag{
 		&cli.StringFlag{
 			Name:        "file",
@@ -30,10 +33,29 @@ func CreateCliCommonFlags(generator *lib.ErdGenerator) []cli.Flag {
 			Destination: &generator.Distance,
 			Value:       0,
 		},
+		&cli.StringFlag{
+			Name:        "from",
+			Usage:       "Output only tables and relations on the shortest paths from `TABLE` to --to table. Relations are labelled with join columns",
+			Required:    false,
+			Destination: &generator.From,
+		},
+		&cli.StringFlag{
+			Name:        "to",
+			Usage:       "Output only tables and relations on the shortest paths from --from table to `TABLE`",
+			Required:    false,
+			Destination: &generator.To,
+		},
+		&cli.IntFlag{
+			Name:        "paths",
+			Usage:       "Output up to `K` paths in ascending order of length instead of all the shortest paths. This option is used only --from and --to",
+			Required:    false,
+			Destination: &generator.Paths,
+			Value:       0,
+		},
 		&cli.BoolFlag{
 			Name:        "skip-index",
 			Aliases:     []string{"i"},
-			Usage:       "Whether don't print index to ERD. This option is used only --format=plant_uml",
+			Usage:       "Whether don't print index to ERD. This option is used only --format=plant_uml or --style=class",
 			Required:    false,
 			Destination: &generator.SKipIndex,
 		},
@@ -44,17 +66,193 @@ func CreateCliCommonFlags(generator *lib.ErdGenerator) []cli.Flag {
 			Required:    false,
 			Destination: &generator.SkipTable,
 		},
+		&cli.BoolFlag{
+			Name:        "infer-foreign-keys",
+			Usage:       "Infer foreign keys which aren't declared from naming convention of columns (e.g. user_id -> users.id). Inferred relations are drawn with dashed line",
+			Required:    false,
+			Destination: &generator.InferForeignKeys,
+		},
+		&cli.StringSliceFlag{
+			Name:        "foreign-key-pattern",
+			Usage:       "`PATTERN` of inferred foreign key as COLUMN=REFERENCED_COLUMN. {table} in COLUMN matches singular or plural name of referenced table (default: {table}_id=id). This option can be specified multiple times and is used only --infer-foreign-keys",
+			Required:    false,
+			Destination: &generator.ForeignKeyPatterns,
+		},
+		&cli.BoolFlag{
+			Name:        "polymorphic",
+			Usage:       "Detect polymorphic associations from <name>_type and <name>_id columns (e.g. commentable_type and commentable_id). Polymorphic relations are drawn with labelled dashed line",
+			Required:    false,
+			Destination: &generator.DetectPolymorphic,
+		},
+		&cli.BoolFlag{
+			Name:        "sample-polymorphic-types",
+			Usage:       "Sample distinct values of <name>_type columns from database to find tables which are referred by polymorphic associations (used only --polymorphic)",
+			Required:    false,
+			Destination: &loadOption.SamplePolymorphicTypes,
+		},
+		&cli.StringSliceFlag{
+			Name:        "skip-column",
+			Usage:       "Skip printing column by using regex `PATTERN` (e.g. created_at|updated_at). This option can be specified multiple times",
+			Required:    false,
+			Destination: &generator.SkipColumns,
+		},
+		&cli.StringFlag{
+			Name:        "columns",
+			Usage:       "Columns which are printed to ERD (`COLUMNS`: all, keys, none. default:all). keys prints only primary key and foreign key columns. This option is used only --format=plant_uml or --format=mermaid",
+			Required:    false,
+			Destination: &generator.Columns,
+		},
 		&cli.StringFlag{
 			Name:        "format",
-			Usage:       "Output format (plant_uml, mermaid. default:plant_uml)",
+			Usage:       fmt.Sprintf("Output format (%s. default:%s)", strings.Join(lib.RendererNames(), ", "), lib.DefaultFormat),
 			Required:    false,
 			Destination: &generator.Format,
 		},
+		&cli.StringFlag{
+			Name:        "template",
+			Usage:       "Render ERD with text/template `FILE` instead of --format. Dot of template is schema (c.f. db.Schema)",
+			Required:    false,
+			Destination: &generator.Template,
+		},
+		&cli.BoolFlag{
+			Name:        "embed-mermaid",
+			Usage:       "Embed Mermaid ERD of adjacent tables into each table section. This option is used only --format=markdown",
+			Required:    false,
+			Destination: &generator.EmbedMermaid,
+		},
+		&cli.StringFlag{
+			Name:        "style",
+			Usage:       "Diagram `STYLE` (er, class. default:er). This option is used only --format=plant_uml or --format=mermaid",
+			Required:    false,
+			Destination: &generator.Style,
+		},
 		&cli.BoolFlag{
 			Name:        "show-comment",
 			Usage:       "Show column comment. This option is used only --format=mermaid",
 			Required:    false,
 			Destination: &generator.ShowComment,
 		},
+		&cli.BoolFlag{
+			Name:        "show-partitions",
+			Usage:       "Show partitions individually instead of collapsing them into their parent table",
+			Required:    false,
+			Destination: &generator.ShowPartitions,
+		},
+		&cli.BoolFlag{
+			Name:        "show-trigger",
+			Usage:       "Draw dashed relations from table to tables which are referenced in its trigger",
+			Required:    false,
+			Destination: &generator.ShowTrigger,
+		},
+		&cli.BoolFlag{
+			Name:        "with-stats",
+			Usage:       "Load estimated row count and size of table, and print them to ERD. This option is used only --format=plant_uml",
+			Required:    false,
+			Destination: &loadOption.WithStats,
+		},
+		&cli.IntFlag{
+			Name:        "min-rows",
+			Usage:       "Output only tables which have at least `ROWS` estimated rows. This option implies --with-stats",
+			Required:    false,
+			Destination: &generator.MinRows,
+			Value:       0,
+			Action: func(_ context.Context, _ *cli.Command, minRows int) error {
+				// Stats are required to filter tables by rows
+				if minRows > 0 {
+					loadOption.WithStats = true
+				}
+				return nil
+			},
+		},
+		&cli.StringFlag{
+			Name:        "group-by",
+			Usage:       "Group tables by `KEY` (schema, prefix). prefix groups tables by the first word of table name (e.g. billing_invoices -> billing)",
+			Required:    false,
+			Destination: &generator.GroupBy,
+		},
+		&cli.StringFlag{
+			Name:        "group-config",
+			Usage:       "Group tables with JSON `FILE` which maps glob patterns of table name to groups instead of --group-by",
+			Required:    false,
+			Destination: &generator.GroupConfig,
+		},
+		&cli.StringFlag{
+			Name:        "group-style",
+			Usage:       "PlantUML block which wraps tables of the same group (`STYLE`: package, namespace, rectangle. default:package). This option is used only --format=plant_uml",
+			Required:    false,
+			Destination: &generator.GroupStyle,
+		},
+		&cli.StringFlag{
+			Name:        "tag-config",
+			Usage:       "Add stereotypes and colors to tables matched with rules in JSON `FILE`. This option is used only --format=plant_uml",
+			Required:    false,
+			Destination: &generator.TagConfig,
+		},
+		&cli.StringFlag{
+			Name:        "theme",
+			Usage:       "PlantUML `THEME` (e.g. cerulean). This option is used only --format=plant_uml",
+			Required:    false,
+			Destination: &generator.Decoration.Theme,
+		},
+		&cli.StringSliceFlag{
+			Name:        "skinparam",
+			Usage:       "PlantUML skinparam `PARAM` which consists of name and value (e.g. \"linetype ortho\"). This option can be specified multiple times and is used only --format=plant_uml",
+			Required:    false,
+			Destination: &generator.Decoration.SkinParams,
+		},
+		&cli.StringFlag{
+			Name:        "title",
+			Usage:       "`TITLE` of ERD. This option is used only --format=plant_uml",
+			Required:    false,
+			Destination: &generator.Decoration.Title,
+		},
+		&cli.StringFlag{
+			Name:        "header",
+			Usage:       "`HEADER` of ERD. This option is used only --format=plant_uml",
+			Required:    false,
+			Destination: &generator.Decoration.Header,
+		},
+		&cli.StringFlag{
+			Name:        "footer",
+			Usage:       "`FOOTER` of ERD. This option is used only --format=plant_uml",
+			Required:    false,
+			Destination: &generator.Decoration.Footer,
+		},
+		&cli.StringFlag{
+			Name:        "legend",
+			Usage:       "`LEGEND` of ERD. This can be multiline. This option is used only --format=plant_uml",
+			Required:    false,
+			Destination: &generator.Decoration.Legend,
+		},
+		&cli.StringFlag{
+			Name:        "relation-label",
+			Usage:       "Label relations with foreign key columns, constraint name or join condition (`LABEL`: column, name, join). This option is used only --format=plant_uml or --format=mermaid",
+			Required:    false,
+			Destination: &generator.RelationLabel,
+		},
+		&cli.BoolFlag{
+			Name:        "relation-column",
+			Usage:       "Connect relations to foreign key columns instead of tables. This option is used only --format=plant_uml",
+			Required:    false,
+			Destination: &generator.RelationColumn,
+		},
+		&cli.StringFlag{
+			Name:        "sort-by",
+			Usage:       "Sort tables by `KEY` (name, rows, size). rows and size are used only --with-stats",
+			Required:    false,
+			Destination: &generator.SortBy,
+		},
+		&cli.StringFlag{
+			Name:        "split-dir",
+			Usage:       "Write one file per table and README.md into `DIR` instead of --file (--format=markdown only). With --split, write one diagram per cluster and index diagram of clusters instead",
+			Required:    false,
+			Destination: &generator.SplitDir,
+		},
+		&cli.StringFlag{
+			Name:        "split",
+			Usage:       "Split schema into `MODE` (component, cluster). component splits into tables which are connected with foreign keys, and cluster splits into closely related tables. This option requires --split-dir",
+			Required:    false,
+			Destination: &generator.Split,
+		},
 	}
 }
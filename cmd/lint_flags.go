@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/sue445/plant_erd/lib"
+	"github.com/urfave/cli/v3"
+)
+
+// CreateCliLintFlags returns flags for lint command
+func CreateCliLintFlags(linter *lib.Linter) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:        "file",
+			Aliases:     []string{"f"},
+			Usage:       "`FILE` for output (default: stdout)",
+			Required:    false,
+			Destination: &linter.Filepath,
+		},
+		&cli.StringFlag{
+			Name:        "format",
+			Usage:       "Output `FORMAT` (text, json, sarif. default:text)",
+			Required:    false,
+			Destination: &linter.Format,
+		},
+		&cli.StringFlag{
+			Name:        "config",
+			Usage:       "JSON `FILE` which enables or disables rules and changes their severity",
+			Required:    false,
+			Destination: &linter.Config,
+		},
+		&cli.StringFlag{
+			Name:        "fail-level",
+			Usage:       "Exit with non-zero code when problems of `SEVERITY` or higher are found (error, warning, note. default:note)",
+			Required:    false,
+			Destination: &linter.FailLevel,
+		},
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/sue445/plant_erd/adapter/oracle"
 	"github.com/sue445/plant_erd/cmd"
+	"github.com/sue445/plant_erd/db"
 	"github.com/sue445/plant_erd/lib"
 	"github.com/urfave/cli/v3"
 	"log"
@@ -23,68 +24,107 @@ var (
 
 func main() {
 	generator := lib.NewErdGenerator()
-	commonFlags := cmd.CreateCliCommonFlags(generator)
+	loadOption := lib.NewLoadSchemaOption()
+	commonFlags := cmd.CreateCliCommonFlags(generator, loadOption)
+	linter := lib.NewLinter()
+	lintFlags := cmd.CreateCliLintFlags(linter)
 
 	oracleConfig := oracle.NewConfig()
 
+	// Oracle flags are persistent flags of root command, so lint subcommand inherits them instead of declaring its own.
+	// (Required flags of root command are checked even if subcommand runs)
+	oracleFlags := []cli.Flag{
+		&cli.StringFlag{
+			Name:        "user",
+			Usage:       "Oracle `USER`",
+			Required:    true,
+			Destination: &oracleConfig.Username,
+		},
+		&cli.StringFlag{
+			Name:        "password",
+			Usage:       "Oracle `PASSWORD`",
+			Required:    false,
+			Destination: &oracleConfig.Password,
+			Sources:     cli.EnvVars("ORACLE_PASSWORD"),
+		},
+		&cli.StringFlag{
+			Name:        "host",
+			Usage:       "Oracle `HOST`",
+			Required:    false,
+			Destination: &oracleConfig.Host,
+			Value:       "localhost",
+		},
+		&cli.IntFlag{
+			Name:        "port",
+			Usage:       "Oracle `PORT`",
+			Required:    false,
+			Destination: &oracleConfig.Port,
+			Value:       1521,
+		},
+		&cli.StringFlag{
+			Name:        "service",
+			Usage:       "Oracle `SERVICE` name",
+			Required:    true,
+			Destination: &oracleConfig.ServiceName,
+		},
+	}
+
+	loadSchema := func(option *lib.LoadSchemaOption) (*db.Schema, error) {
+		adapter, closeDatabase, err := oracle.NewAdapter(oracleConfig)
+
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		defer closeDatabase() //nolint:errcheck
+
+		schema, err := lib.LoadSchema(adapter, option)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		return schema, nil
+	}
+
 	command := &cli.Command{
 		Name:    "plant_erd-oracle",
 		Version: fmt.Sprintf("%s (build. %s)", Version, Revision),
 		Usage:   "ERD exporter with PlantUML and Mermaid format (for oracle)",
-		Flags: append(
-			commonFlags,
-			&cli.StringFlag{
-				Name:        "user",
-				Usage:       "Oracle `USER`",
-				Required:    true,
-				Destination: &oracleConfig.Username,
-			},
-			&cli.StringFlag{
-				Name:        "password",
-				Usage:       "Oracle `PASSWORD`",
-				Required:    false,
-				Destination: &oracleConfig.Password,
-				Sources:     cli.EnvVars("ORACLE_PASSWORD"),
-			},
-			&cli.StringFlag{
-				Name:        "host",
-				Usage:       "Oracle `HOST`",
-				Required:    false,
-				Destination: &oracleConfig.Host,
-				Value:       "localhost",
-			},
-			&cli.IntFlag{
-				Name:        "port",
-				Usage:       "Oracle `PORT`",
-				Required:    false,
-				Destination: &oracleConfig.Port,
-				Value:       1521,
-			},
-			&cli.StringFlag{
-				Name:        "service",
-				Usage:       "Oracle `SERVICE` name",
-				Required:    true,
-				Destination: &oracleConfig.ServiceName,
-			},
-		),
+		Flags:   append(append([]cli.Flag{}, commonFlags...), oracleFlags...),
 		Action: func(_ context.Context, _ *cli.Command) error {
-			adapter, closeDatabase, err := oracle.NewAdapter(oracleConfig)
-
+			schema, err := loadSchema(loadOption)
 			if err != nil {
 				return errors.WithStack(err)
 			}
 
-			defer closeDatabase() //nolint:errcheck
+			return generator.Run(schema)
+		},
+		Commands: []*cli.Command{
+			{
+				Name:  "lint",
+				Usage: "Lint schema (tables without primary key, foreign keys without index and so on)",
+				Flags: lintFlags,
+				Action: func(_ context.Context, _ *cli.Command) error {
+					schema, err := loadSchema(lib.NewLoadSchemaOption())
+					if err != nil {
+						return errors.WithStack(err)
+					}
 
-			schema, err := lib.LoadSchema(adapter)
-			if err != nil {
-				return errors.WithStack(err)
-			}
+					failed, err := linter.Run(schema)
+					if err != nil {
+						return errors.WithStack(err)
+					}
 
-			return generator.Run(schema) //nolint:errcheck
+					if failed {
+						// Exit with non-zero code for CI
+						return cli.Exit("", 1)
+					}
+
+					return nil
+				},
+			},
 		},
 	}
-
 	// Sort commands
 	sort.Slice(command.Commands, func(i, j int) bool {
 		return command.Commands[i].Name < command.Commands[j].Name
@@ -5,10 +5,16 @@ import (
 	"fmt"
 	"github.com/cockroachdb/errors"
 	mysqlDriver "github.com/go-sql-driver/mysql"
+	"github.com/sue445/plant_erd/adapter"
+	"github.com/sue445/plant_erd/adapter/gostruct"
+	"github.com/sue445/plant_erd/adapter/migrations"
 	"github.com/sue445/plant_erd/adapter/mysql"
 	"github.com/sue445/plant_erd/adapter/postgresql"
+	"github.com/sue445/plant_erd/adapter/prisma"
+	"github.com/sue445/plant_erd/adapter/rails"
 	"github.com/sue445/plant_erd/adapter/sqlite3"
 	"github.com/sue445/plant_erd/cmd"
+	"github.com/sue445/plant_erd/db"
 	"github.com/sue445/plant_erd/lib"
 	"github.com/urfave/cli/v3"
 	"log"
@@ -26,55 +32,49 @@ var (
 
 func main() {
 	generator := lib.NewErdGenerator()
-	commonFlags := cmd.CreateCliCommonFlags(generator)
+	loadOption := lib.NewLoadSchemaOption()
+	commonFlags := cmd.CreateCliCommonFlags(generator, loadOption)
+	linter := lib.NewLinter()
+	lintFlags := cmd.CreateCliLintFlags(linter)
 
 	sqlite3Database := ""
 	mysqlConfig := mysqlDriver.NewConfig()
 	mysqlHost := ""
 	mysqlPort := 0
 	postgresqlConfig := postgresql.NewConfig()
+	railsSchema := ""
+	gostructDir := ""
+	prismaSchema := ""
+	migrationsDir := ""
+	migrationsDialect := ""
+	migrationsUntil := ""
 
-	command := &cli.Command{
-		Name:    "plant_erd",
-		Version: fmt.Sprintf("%s (build. %s)", Version, Revision),
-		Usage:   "ERD exporter with PlantUML and Mermaid format",
-		Commands: []*cli.Command{
-			{
-				Name:    "sqlite3",
-				Aliases: []string{"s"},
-				Usage:   "Generate ERD from sqlite3",
-				Flags: append(
-					commonFlags,
+	sources := []*source{
+		{
+			name:    "sqlite3",
+			aliases: []string{"s"},
+			from:    "sqlite3",
+			flags: func() []cli.Flag {
+				return []cli.Flag{
 					&cli.StringFlag{
 						Name:        "database",
 						Usage:       "SQLite3 `DATABASE` file",
 						Required:    true,
 						Destination: &sqlite3Database,
 					},
-				),
-				Action: func(_ context.Context, _ *cli.Command) error {
-					adapter, closeDatabase, err := sqlite3.NewAdapter(sqlite3Database)
-
-					if err != nil {
-						return errors.WithStack(err)
-					}
-
-					defer closeDatabase() //nolint:errcheck
-
-					schema, err := lib.LoadSchema(adapter)
-					if err != nil {
-						return errors.WithStack(err)
-					}
-
-					return generator.Run(schema) //nolint:errcheck
-				},
+				}
 			},
-			{
-				Name:    "mysql",
-				Aliases: []string{"m"},
-				Usage:   "Generate ERD from mysql",
-				Flags: append(
-					commonFlags,
+			open: func() (adapter.Adapter, func() error, error) {
+				return sqlite3.NewAdapter(sqlite3Database)
+			},
+			uri: &sqlite3Database,
+		},
+		{
+			name:    "mysql",
+			aliases: []string{"m"},
+			from:    "mysql",
+			flags: func() []cli.Flag {
+				return []cli.Flag{
 					&cli.StringFlag{
 						Name:        "host",
 						Usage:       "MySQL `HOST`",
@@ -116,33 +116,21 @@ func main() {
 						Destination: &mysqlConfig.Collation,
 						Value:       "utf8_general_ci",
 					},
-				),
-				Action: func(_ context.Context, _ *cli.Command) error {
-					mysqlConfig.Net = "tcp"
-					mysqlConfig.Addr = fmt.Sprintf("%s:%d", mysqlHost, mysqlPort)
-
-					adapter, closeDatabase, err := mysql.NewAdapter(mysqlConfig)
-
-					if err != nil {
-						return errors.WithStack(err)
-					}
-
-					defer closeDatabase() //nolint:errcheck
-
-					schema, err := lib.LoadSchema(adapter)
-					if err != nil {
-						return errors.WithStack(err)
-					}
+				}
+			},
+			open: func() (adapter.Adapter, func() error, error) {
+				mysqlConfig.Net = "tcp"
+				mysqlConfig.Addr = fmt.Sprintf("%s:%d", mysqlHost, mysqlPort)
 
-					return generator.Run(schema) //nolint:errcheck
-				},
+				return mysql.NewAdapter(mysqlConfig)
 			},
-			{
-				Name:    "postgresql",
-				Aliases: []string{"p"},
-				Usage:   "Generate ERD from PostgreSQL",
-				Flags: append(
-					commonFlags,
+		},
+		{
+			name:    "postgresql",
+			aliases: []string{"p"},
+			from:    "PostgreSQL",
+			flags: func() []cli.Flag {
+				return []cli.Flag{
 					&cli.StringFlag{
 						Name:        "host",
 						Usage:       "PostgreSQL `HOST`",
@@ -183,39 +171,231 @@ func main() {
 						Destination: &postgresqlConfig.SslMode,
 						Value:       "disable",
 					},
-				),
-				Action: func(_ context.Context, _ *cli.Command) error {
-					adapter, closeDatabase, err := postgresql.NewAdapter(postgresqlConfig)
+				}
+			},
+			open: func() (adapter.Adapter, func() error, error) {
+				return postgresql.NewAdapter(postgresqlConfig)
+			},
+		},
+		{
+			name:    "rails",
+			aliases: []string{"r"},
+			from:    "Rails db/schema.rb",
+			flags: func() []cli.Flag {
+				return []cli.Flag{
+					&cli.StringFlag{
+						Name:        "schema",
+						Usage:       "Rails schema `FILE`",
+						Required:    false,
+						Destination: &railsSchema,
+						Value:       "db/schema.rb",
+					},
+				}
+			},
+			open: func() (adapter.Adapter, func() error, error) {
+				a, err := rails.NewAdapter(railsSchema)
+				return a, nil, err
+			},
+			uri: &railsSchema,
+		},
+		{
+			name:    "gostruct",
+			aliases: []string{"g"},
+			from:    "GORM model structs in Go package",
+			flags: func() []cli.Flag {
+				return []cli.Flag{
+					&cli.StringFlag{
+						Name:        "dir",
+						Usage:       "Go package `DIR` which contains model structs",
+						Required:    false,
+						Destination: &gostructDir,
+						Value:       ".",
+					},
+				}
+			},
+			open: func() (adapter.Adapter, func() error, error) {
+				a, err := gostruct.NewAdapter(gostructDir)
+				return a, nil, err
+			},
+			uri: &gostructDir,
+		},
+		{
+			name: "prisma",
+			from: "Prisma schema",
+			flags: func() []cli.Flag {
+				return []cli.Flag{
+					&cli.StringFlag{
+						Name:        "schema",
+						Usage:       "Prisma schema `FILE`",
+						Required:    false,
+						Destination: &prismaSchema,
+						Value:       "prisma/schema.prisma",
+					},
+				}
+			},
+			open: func() (adapter.Adapter, func() error, error) {
+				a, err := prisma.NewAdapter(prismaSchema)
+				return a, nil, err
+			},
+			uri: &prismaSchema,
+		},
+		{
+			name: "migrations",
+			from: "migration files which are applied to a temporary database",
+			flags: func() []cli.Flag {
+				return []cli.Flag{
+					&cli.StringFlag{
+						Name:        "dir",
+						Usage:       "Migration `DIR` (golang-migrate, goose or Flyway naming conventions)",
+						Required:    true,
+						Destination: &migrationsDir,
+					},
+					&cli.StringFlag{
+						Name:        "dialect",
+						Usage:       "SQL `DIALECT` of migration files (sqlite)",
+						Required:    false,
+						Destination: &migrationsDialect,
+						Value:       "sqlite",
+					},
+					&cli.StringFlag{
+						Name:        "until",
+						Usage:       "Apply only migrations up to and including `VERSION`",
+						Required:    false,
+						Destination: &migrationsUntil,
+					},
+				}
+			},
+			open: func() (adapter.Adapter, func() error, error) {
+				return migrations.NewAdapter(migrationsDir, migrationsDialect, migrationsUntil)
+			},
+			uri: &migrationsDir,
+		},
+	}
+
+	var commands []*cli.Command
+	var lintCommands []*cli.Command
+	for _, s := range sources {
+		commands = append(commands, &cli.Command{
+			Name:    s.name,
+			Aliases: s.aliases,
+			Usage:   "Generate ERD from " + s.from,
+			Flags:   concatFlags(commonFlags, s.flags()),
+			Action: func(_ context.Context, _ *cli.Command) error {
+				schema, err := s.loadSchema(loadOption)
+				if err != nil {
+					return errors.WithStack(err)
+				}
+
+				return generator.Run(schema)
+			},
+		})
 
-					if err != nil {
-						return errors.WithStack(err)
-					}
+		lintCommands = append(lintCommands, &cli.Command{
+			Name:    s.name,
+			Aliases: s.aliases,
+			Usage:   "Lint schema from " + s.from,
+			Flags:   concatFlags(lintFlags, s.flags()),
+			Action: func(_ context.Context, _ *cli.Command) error {
+				schema, err := s.loadSchema(lib.NewLoadSchemaOption())
+				if err != nil {
+					return errors.WithStack(err)
+				}
 
-					defer closeDatabase() //nolint:errcheck
+				if s.uri != nil {
+					linter.SourceURI = *s.uri
+				}
 
-					schema, err := lib.LoadSchema(adapter)
-					if err != nil {
-						return errors.WithStack(err)
-					}
+				failed, err := linter.Run(schema)
+				if err != nil {
+					return errors.WithStack(err)
+				}
 
-					return generator.Run(schema) //nolint:errcheck
-				},
+				if failed {
+					// Exit with non-zero code for CI
+					return cli.Exit("", 1)
+				}
+
+				return nil
 			},
-		},
+		})
+	}
+
+	commands = append(commands, &cli.Command{
+		Name:     "lint",
+		Usage:    "Lint schema (tables without primary key, foreign keys without index and so on)",
+		Commands: lintCommands,
+	})
+
+	command := &cli.Command{
+		Name:     "plant_erd",
+		Version:  fmt.Sprintf("%s (build. %s)", Version, Revision),
+		Usage:    "ERD exporter with PlantUML and Mermaid format",
+		Commands: commands,
 	}
 
 	// Sort commands
 	sort.Slice(command.Commands, func(i, j int) bool {
 		return command.Commands[i].Name < command.Commands[j].Name
 	})
+	sort.Slice(lintCommands, func(i, j int) bool {
+		return lintCommands[i].Name < lintCommands[j].Name
+	})
 
 	// Sort sub-command flags
 	for _, c := range command.Commands {
 		sort.Sort(cli.FlagsByName(c.Flags))
 	}
+	for _, c := range lintCommands {
+		sort.Sort(cli.FlagsByName(c.Flags))
+	}
 
 	err := command.Run(context.Background(), os.Args)
 	if err != nil {
 		log.Fatal(err)
 	}
 }
+
+// source represents where schema is loaded from
+type source struct {
+	name    string
+	aliases []string
+
+	// from represents description of source which is used in usage of command
+	from string
+
+	// flags returns flags of source. Each command needs its own flag instances because flags hold parsed state
+	flags func() []cli.Flag
+
+	// open returns adapter and function which closes it (nil when adapter doesn't need to be closed)
+	open func() (adapter.Adapter, func() error, error)
+
+	// uri represents path of schema file which is used as location of lint results. This is nil when source isn't a file (e.g. mysql)
+	uri *string
+}
+
+func (s *source) loadSchema(loadOption *lib.LoadSchemaOption) (*db.Schema, error) {
+	a, closeAdapter, err := s.open()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if closeAdapter != nil {
+		defer closeAdapter() //nolint:errcheck
+	}
+
+	schema, err := lib.LoadSchema(a, loadOption)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return schema, nil
+}
+
+// concatFlags returns a new slice of flags so that sorting flags of a command doesn't affect others
+func concatFlags(flags ...[]cli.Flag) []cli.Flag {
+	var concatenated []cli.Flag
+	for _, f := range flags {
+		concatenated = append(concatenated, f...)
+	}
+	return concatenated
+}
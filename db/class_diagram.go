@@ -0,0 +1,190 @@
+package db
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// mermaidClassNameRe matches class name which can be used without quotes in Mermaid
+var mermaidClassNameRe = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// ToClassDiagram returns PlantUML class diagram formatted schema. Tables which have Group are wrapped in option.GroupStyle block (package, namespace, rectangle)
+func (s *Schema) ToClassDiagram(option DiagramOption) string {
+	lines := []string{"hide empty methods"}
+	resolver := NewTableResolver(s.Tables)
+	entityNames := s.plantUmlEntityNames(option.GroupStyle)
+
+	for _, group := range s.tableGroups() {
+		var classes []string
+		for _, table := range group.tables {
+			classes = append(classes, table.ToClassDiagram(option.ShowIndex, option.Columns))
+		}
+
+		if group.name == "" {
+			lines = append(lines, classes...)
+		} else {
+			lines = append(lines, wrapPlantUmlGroup(option.GroupStyle, group.name, classes))
+		}
+	}
+
+	for _, table := range s.Tables {
+		for _, foreignKeys := range groupForeignKeys(table.ForeignKeys) {
+			if target := resolver.Find(foreignKeys[0].ToTable); target != nil {
+				lines = append(lines, fmt.Sprintf("%s \"0..*\" %s \"%s\" %s : %s", entityNames[table.Name], classRelationArrow(foreignKeys[0]), table.foreignKeyMultiplicity(foreignKeys[0]), entityNames[target.Name], option.Relation.classRelationLabel(foreignKeys)))
+			}
+		}
+	}
+
+	if option.ShowTrigger {
+		for _, relation := range s.triggerRelations() {
+			lines = append(lines, fmt.Sprintf("%s ..> %s : %s", entityNames[relation.fromTable], entityNames[relation.toTable], relation.trigger.Name))
+		}
+	}
+
+	return strings.Join(lines, "\n\n")
+}
+
+// ToMermaidClassDiagram returns Mermaid classDiagram formatted schema. Tables which have Group are wrapped in namespace
+func (s *Schema) ToMermaidClassDiagram(option DiagramOption) string {
+	lines := []string{"classDiagram"}
+	resolver := NewTableResolver(s.Tables)
+
+	for _, group := range s.tableGroups() {
+		var classes []string
+		for _, table := range group.tables {
+			classes = append(classes, table.ToMermaidClassDiagram(option.ShowIndex, option.Columns))
+		}
+
+		if group.name == "" {
+			lines = append(lines, classes...)
+			continue
+		}
+
+		namespace := []string{fmt.Sprintf("namespace %s {", mermaidNamespaceName(group.name))}
+		for _, class := range classes {
+			namespace = append(namespace, indent(class))
+		}
+		namespace = append(namespace, "}")
+		lines = append(lines, strings.Join(namespace, "\n"))
+	}
+
+	for _, table := range s.Tables {
+		for _, foreignKeys := range groupForeignKeys(table.ForeignKeys) {
+			if target := resolver.Find(foreignKeys[0].ToTable); target != nil {
+				toTable := target.Name
+				lines = append(lines, fmt.Sprintf("%s \"0..*\" %s \"%s\" %s : %s", mermaidClassName(table.Name), classRelationArrow(foreignKeys[0]), table.foreignKeyMultiplicity(foreignKeys[0]), mermaidClassName(toTable), option.Relation.classRelationLabel(foreignKeys)))
+			}
+		}
+	}
+
+	if option.ShowTrigger {
+		for _, relation := range s.triggerRelations() {
+			lines = append(lines, fmt.Sprintf("%s ..> %s : %s", mermaidClassName(relation.fromTable), mermaidClassName(relation.toTable), relation.trigger.Name))
+		}
+	}
+
+	return strings.Join(lines, "\n\n")
+}
+
+// ToClassDiagram returns PlantUML class formatted table
+func (t *Table) ToClassDiagram(showIndex bool, columnMode string) string {
+	lines := []string{
+		fmt.Sprintf("class %s {", strings.Join(append([]string{t.Name}, t.plantUmlTags()...), " ")),
+	}
+
+	for _, column := range t.visibleColumns(columnMode) {
+		line := "  {field} " + column.ToErd()
+		if key := t.columnKey(column); key != "" {
+			line += " <<" + key + ">>"
+		}
+		lines = append(lines, line)
+	}
+
+	if showIndex && columnMode != "none" && len(t.Indexes) > 0 {
+		lines = append(lines, "  .. indexes ..")
+		for _, index := range t.Indexes {
+			lines = append(lines, "  {method} "+index.toClassMethod())
+		}
+	}
+
+	lines = append(lines, "}")
+	return strings.Join(lines, "\n")
+}
+
+// ToMermaidClassDiagram returns Mermaid classDiagram formatted table
+func (t *Table) ToMermaidClassDiagram(showIndex bool, columnMode string) string {
+	var members []string
+
+	for _, column := range t.visibleColumns(columnMode) {
+		// Type keeps parentheses (e.g. varchar(255)) because Mermaid renders member line as literal text
+		parts := []string{column.Type, column.Name}
+		if key := t.columnKey(column); key != "" {
+			parts = append(parts, key)
+		}
+		if column.NotNull && !column.PrimaryKey {
+			parts = append(parts, "NOT NULL")
+		}
+		members = append(members, "  "+strings.Join(parts, " "))
+	}
+
+	if showIndex && columnMode != "none" {
+		for _, index := range t.Indexes {
+			members = append(members, "  "+index.toClassMethod())
+		}
+	}
+
+	if len(members) == 0 {
+		// Mermaid allows class without members block
+		return "class " + mermaidClassName(t.Name)
+	}
+
+	lines := []string{fmt.Sprintf("class %s {", mermaidClassName(t.Name))}
+	lines = append(lines, members...)
+	lines = append(lines, "}")
+	return strings.Join(lines, "\n")
+}
+
+// mermaidClassName returns class name which is quoted with backticks when it isn't valid identifier of Mermaid (e.g. public.users)
+func mermaidClassName(name string) string {
+	if mermaidClassNameRe.MatchString(name) {
+		return name
+	}
+	// Backtick can't be escaped in quoted class name
+	return "`" + strings.ReplaceAll(name, "`", "") + "`"
+}
+
+// classRelationLabel returns label of relation in class diagram. Class diagram is labelled with foreign key columns by default
+func (o RelationOption) classRelationLabel(foreignKeys []*ForeignKey) string {
+	if o.Label == "" {
+		o.Label = "column"
+	}
+	return o.relationLabel(foreignKeys)
+}
+
+// classRelationArrow returns arrow of relation. Inferred relation is drawn with dashed line
+func classRelationArrow(foreignKey *ForeignKey) string {
+	if foreignKey.Inferred {
+		return "..>"
+	}
+	return "-->"
+}
+
+// foreignKeyMultiplicity returns multiplicity of referenced side of foreign key
+func (t *Table) foreignKeyMultiplicity(foreignKey *ForeignKey) string {
+	if column := t.findColumn(foreignKey.FromColumn); column != nil && column.NotNull {
+		return "1"
+	}
+	return "0..1"
+}
+
+// toClassMethod returns index as method of class (e.g. UNIQUE index_users_on_email(email))
+func (i *Index) toClassMethod() string {
+	str := i.Name + i.Definition()
+
+	if i.Unique {
+		str = "UNIQUE " + str
+	}
+
+	return str
+}
@@ -0,0 +1,223 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func classDiagramTestSchema() *Schema {
+	return NewSchema([]*Table{
+		{
+			Name: "articles",
+			Columns: []*Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+				{Name: "user_id", Type: "integer", NotNull: true},
+				{Name: "editor_id", Type: "integer"},
+				{Name: "title", Type: "varchar(255)"},
+			},
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+				{FromColumn: "editor_id", ToTable: "users", ToColumn: "id"},
+			},
+			Indexes: []*Index{
+				{Name: "index_title_on_articles", Columns: []string{"title"}, Unique: true},
+			},
+			Triggers: []*Trigger{
+				{Name: "touch_users", Body: "UPDATE users SET updated_at = now()"},
+			},
+		},
+		{
+			Name: "users",
+			Columns: []*Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+			},
+		},
+	})
+}
+
+func TestSchema_ToClassDiagram(t *testing.T) {
+	type args struct {
+		showIndex   bool
+		showTrigger bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "with index",
+			args: args{showIndex: true},
+			want: `hide empty methods
+
+class articles {
+  {field} * id : integer <<PK>>
+  {field} * user_id : integer <<FK>>
+  {field} editor_id : integer <<FK>>
+  {field} title : varchar(255)
+  .. indexes ..
+  {method} UNIQUE index_title_on_articles(title)
+}
+
+class users {
+  {field} * id : integer <<PK>>
+}
+
+articles "0..*" --> "1" users : user_id
+
+articles "0..*" --> "0..1" users : editor_id`,
+		},
+		{
+			name: "without index and with trigger",
+			args: args{showIndex: false, showTrigger: true},
+			want: `hide empty methods
+
+class articles {
+  {field} * id : integer <<PK>>
+  {field} * user_id : integer <<FK>>
+  {field} editor_id : integer <<FK>>
+  {field} title : varchar(255)
+}
+
+class users {
+  {field} * id : integer <<PK>>
+}
+
+articles "0..*" --> "1" users : user_id
+
+articles "0..*" --> "0..1" users : editor_id
+
+articles ..> users : touch_users`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := classDiagramTestSchema()
+			assert.Equal(t, tt.want, s.ToClassDiagram(DiagramOption{ShowIndex: tt.args.showIndex, ShowTrigger: tt.args.showTrigger}))
+		})
+	}
+}
+
+func TestSchema_ToMermaidClassDiagram(t *testing.T) {
+	type args struct {
+		showIndex   bool
+		showTrigger bool
+		columnMode  string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "with index",
+			args: args{showIndex: true},
+			want: `classDiagram
+
+class articles {
+  integer id PK
+  integer user_id FK NOT NULL
+  integer editor_id FK
+  varchar(255) title
+  UNIQUE index_title_on_articles(title)
+}
+
+class users {
+  integer id PK
+}
+
+articles "0..*" --> "1" users : user_id
+
+articles "0..*" --> "0..1" users : editor_id`,
+		},
+		{
+			name: "without index and with trigger",
+			args: args{showIndex: false, showTrigger: true},
+			want: `classDiagram
+
+class articles {
+  integer id PK
+  integer user_id FK NOT NULL
+  integer editor_id FK
+  varchar(255) title
+}
+
+class users {
+  integer id PK
+}
+
+articles "0..*" --> "1" users : user_id
+
+articles "0..*" --> "0..1" users : editor_id
+
+articles ..> users : touch_users`,
+		},
+		{
+			name: "without columns",
+			args: args{showIndex: true, columnMode: "none"},
+			want: `classDiagram
+
+class articles
+
+class users
+
+articles "0..*" --> "1" users : user_id
+
+articles "0..*" --> "0..1" users : editor_id`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := classDiagramTestSchema()
+			assert.Equal(t, tt.want, s.ToMermaidClassDiagram(DiagramOption{ShowIndex: tt.args.showIndex, ShowTrigger: tt.args.showTrigger, Columns: tt.args.columnMode}))
+		})
+	}
+}
+
+func TestSchema_ToMermaidClassDiagram_withSchemaName(t *testing.T) {
+	s := NewSchema([]*Table{
+		{
+			Name: "public.articles",
+			Columns: []*Column{
+				{Name: "id", Type: "integer", PrimaryKey: true},
+				{Name: "user_id", Type: "integer", NotNull: true},
+			},
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "user_id", ToTable: "public.users", ToColumn: "id"},
+			},
+		},
+		{
+			Name: "public.users",
+		},
+	})
+
+	want := "classDiagram\n\n" +
+		"class `public.articles` {\n" +
+		"  integer id PK\n" +
+		"  integer user_id FK NOT NULL\n" +
+		"}\n\n" +
+		"class `public.users`\n\n" +
+		"`public.articles` \"0..*\" --> \"1\" `public.users` : user_id"
+
+	assert.Equal(t, want, s.ToMermaidClassDiagram(DiagramOption{}))
+}
+
+func TestTable_ToMermaidClassDiagram_withParenthesesType(t *testing.T) {
+	table := &Table{
+		Name: "prices",
+		Columns: []*Column{
+			{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+			{Name: "amount", Type: "decimal(10,2)", NotNull: true},
+			{Name: "currency", Type: "char(3)"},
+		},
+	}
+
+	want := "class prices {\n" +
+		"  integer id PK\n" +
+		"  decimal(10,2) amount NOT NULL\n" +
+		"  char(3) currency\n" +
+		"}"
+
+	assert.Equal(t, want, table.ToMermaidClassDiagram(false, ""))
+}
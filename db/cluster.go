@@ -0,0 +1,311 @@
+package db
+
+import (
+	"fmt"
+	"github.com/deckarep/golang-set/v2"
+	"sort"
+	"strings"
+)
+
+// IsolatedClusterName represents name of cluster which has tables without any relations
+const IsolatedClusterName = "isolated"
+
+// Cluster represents tables which are closely related with foreign keys
+type Cluster struct {
+	// Name represents name of cluster. This is the name of table which has the most relations in cluster
+	Name   string
+	Tables []*Table
+}
+
+// SplitByComponent returns connected components of schema. Tables which don't have any relations are put together into a cluster
+func (s *Schema) SplitByComponent() []*Cluster {
+	graph := s.relationGraph()
+
+	components := map[string]int{}
+	for i, table := range s.Tables {
+		if _, ok := components[table.Name]; ok {
+			continue
+		}
+
+		// Mark connected tables with breadth-first search
+		components[table.Name] = i
+		queue := []string{table.Name}
+		for len(queue) > 0 {
+			tableName := queue[0]
+			queue = queue[1:]
+
+			for _, other := range graph.GetRowColumns(tableName) {
+				if _, ok := components[other]; !ok {
+					components[other] = i
+					queue = append(queue, other)
+				}
+			}
+		}
+	}
+
+	return s.buildClusters(graph, components)
+}
+
+// SplitByCommunity returns community clusters of schema which are detected with Louvain method.
+// This is useful when most tables are connected each other (e.g. almost all tables refer users)
+func (s *Schema) SplitByCommunity() []*Cluster {
+	graph := s.relationGraph()
+
+	var tableNames []string
+	indexes := map[string]int{}
+	for _, table := range s.Tables {
+		indexes[table.Name] = len(tableNames)
+		tableNames = append(tableNames, table.Name)
+	}
+
+	weights := make([]map[int]float64, len(tableNames))
+	for i, tableName := range tableNames {
+		weights[i] = map[int]float64{}
+		for _, other := range graph.GetRowColumns(tableName) {
+			weights[i][indexes[other]] = 1
+		}
+	}
+
+	communities := louvain(weights)
+
+	components := map[string]int{}
+	for i, tableName := range tableNames {
+		components[tableName] = communities[i]
+	}
+
+	return s.buildClusters(graph, components)
+}
+
+// ClusterIndex returns schema which represents clusters as tables. Each table has member tables of cluster as columns, and has foreign keys to other clusters which are referred by member tables
+func (s *Schema) ClusterIndex(clusters []*Cluster) *Schema {
+	clusterNames := map[string]string{}
+	var clusterTables []*Table
+	for _, cluster := range clusters {
+		for _, table := range cluster.Tables {
+			clusterNames[table.Name] = cluster.Name
+			clusterTables = append(clusterTables, table)
+		}
+	}
+	resolver := NewTableResolver(clusterTables)
+
+	var tables []*Table
+	for _, cluster := range clusters {
+		index := &Table{Name: cluster.Name}
+		referredClusters := mapset.NewSet[string]()
+
+		for _, table := range cluster.Tables {
+			index.Columns = append(index.Columns, &Column{Name: table.Name, Type: "table"})
+
+			for _, foreignKey := range table.ForeignKeys {
+				target := resolver.Find(foreignKey.ToTable)
+				if target == nil {
+					continue
+				}
+
+				toCluster := clusterNames[target.Name]
+				if toCluster == cluster.Name || referredClusters.Contains(toCluster) {
+					continue
+				}
+
+				referredClusters.Add(toCluster)
+				index.ForeignKeys = append(index.ForeignKeys, &ForeignKey{FromColumn: table.Name, ToTable: toCluster, ToColumn: target.Name})
+			}
+		}
+
+		tables = append(tables, index)
+	}
+
+	return NewSchema(tables)
+}
+
+// relationGraph returns graph of tables which are connected with foreign keys. Foreign keys to tables outside schema and to itself are ignored
+func (s *Schema) relationGraph() *UndirectedGraph {
+	resolver := NewTableResolver(s.Tables)
+
+	graph := NewUndirectedGraph()
+	for _, table := range s.Tables {
+		for _, foreignKey := range table.ForeignKeys {
+			if target := resolver.Find(foreignKey.ToTable); target != nil && target != table {
+				graph.PutSymmetric(table.Name, target.Name, true)
+			}
+		}
+	}
+
+	return graph
+}
+
+// buildClusters returns clusters which are grouped by components (table name -> component ID).
+// Clusters are sorted by number of tables in descending order, and cluster of isolated tables is the last
+func (s *Schema) buildClusters(graph *UndirectedGraph, components map[string]int) []*Cluster {
+	var clusterIDs []int
+	tablesByID := map[int][]*Table{}
+	var isolatedTables []*Table
+
+	for _, table := range s.Tables {
+		if len(graph.GetRowColumns(table.Name)) == 0 {
+			isolatedTables = append(isolatedTables, table)
+			continue
+		}
+
+		id := components[table.Name]
+		if _, ok := tablesByID[id]; !ok {
+			clusterIDs = append(clusterIDs, id)
+		}
+		tablesByID[id] = append(tablesByID[id], table)
+	}
+
+	var clusters []*Cluster
+	for _, id := range clusterIDs {
+		tables := tablesByID[id]
+
+		// Name cluster after table which has the most relations
+		name := tables[0].Name
+		for _, table := range tables[1:] {
+			if len(graph.GetRowColumns(table.Name)) > len(graph.GetRowColumns(name)) {
+				name = table.Name
+			}
+		}
+
+		clusters = append(clusters, &Cluster{Name: name, Tables: tables})
+	}
+
+	sort.SliceStable(clusters, func(i, j int) bool {
+		return len(clusters[i].Tables) > len(clusters[j].Tables)
+	})
+
+	// Table may have the same name as cluster of isolated tables
+	clusters = UniqueClusterNames(clusters, IsolatedClusterName)
+
+	if len(isolatedTables) > 0 {
+		clusters = append(clusters, &Cluster{Name: IsolatedClusterName, Tables: isolatedTables})
+	}
+
+	return clusters
+}
+
+// UniqueClusterNames returns clusters whose names are unique. Names are compared case-insensitively because they are used as file names.
+// Duplicate name and reservedNames are suffixed with number (e.g. users_2)
+func UniqueClusterNames(clusters []*Cluster, reservedNames ...string) []*Cluster {
+	usedNames := mapset.NewSet[string]()
+	for _, name := range reservedNames {
+		usedNames.Add(strings.ToLower(name))
+	}
+
+	var renamed []*Cluster
+	for _, cluster := range clusters {
+		name := cluster.Name
+		for i := 2; usedNames.Contains(strings.ToLower(name)); i++ {
+			name = fmt.Sprintf("%s_%d", cluster.Name, i)
+		}
+		usedNames.Add(strings.ToLower(name))
+
+		renamed = append(renamed, &Cluster{Name: name, Tables: cluster.Tables})
+	}
+
+	return renamed
+}
+
+// louvain returns community of each node which is detected with Louvain method. weights represents symmetric adjacency matrix
+func louvain(weights []map[int]float64) []int {
+	communities := make([]int, len(weights))
+	for i := range communities {
+		communities[i] = i
+	}
+
+	for {
+		moved, levelCommunities := louvainLevel(weights)
+		if !moved {
+			return communities
+		}
+
+		// Aggregate nodes of the same community into a node
+		renumbered := map[int]int{}
+		for _, community := range levelCommunities {
+			if _, ok := renumbered[community]; !ok {
+				renumbered[community] = len(renumbered)
+			}
+		}
+
+		aggregated := make([]map[int]float64, len(renumbered))
+		for i := range aggregated {
+			aggregated[i] = map[int]float64{}
+		}
+		for i, neighbors := range weights {
+			for j, weight := range neighbors {
+				aggregated[renumbered[levelCommunities[i]]][renumbered[levelCommunities[j]]] += weight
+			}
+		}
+
+		for i, community := range communities {
+			communities[i] = renumbered[levelCommunities[community]]
+		}
+		weights = aggregated
+	}
+}
+
+// louvainLevel moves each node to neighbor community which maximizes modularity gain until no node is moved.
+// This returns whether any node is moved and community of each node
+func louvainLevel(weights []map[int]float64) (bool, []int) {
+	communities := make([]int, len(weights))
+	degrees := make([]float64, len(weights))
+	totals := make([]float64, len(weights))
+	total := 0.0
+
+	for i, neighbors := range weights {
+		communities[i] = i
+		for _, weight := range neighbors {
+			degrees[i] += weight
+		}
+		totals[i] = degrees[i]
+		total += degrees[i]
+	}
+
+	if total == 0 {
+		return false, communities
+	}
+
+	moved := false
+	for {
+		changed := false
+
+		for i, neighbors := range weights {
+			current := communities[i]
+			totals[current] -= degrees[i]
+
+			// Sum of weights between node and each neighbor community
+			links := map[int]float64{}
+			var candidates []int
+			for j, weight := range neighbors {
+				if j == i {
+					continue
+				}
+				if _, ok := links[communities[j]]; !ok {
+					candidates = append(candidates, communities[j])
+				}
+				links[communities[j]] += weight
+			}
+			sort.Ints(candidates)
+
+			best := current
+			bestGain := links[current] - totals[current]*degrees[i]/total
+			for _, candidate := range candidates {
+				gain := links[candidate] - totals[candidate]*degrees[i]/total
+				if gain > bestGain+1e-9 {
+					best = candidate
+					bestGain = gain
+				}
+			}
+
+			totals[best] += degrees[i]
+			if best != current {
+				communities[i] = best
+				changed = true
+				moved = true
+			}
+		}
+
+		if !changed {
+			return moved, communities
+		}
+	}
+}
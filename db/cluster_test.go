@@ -0,0 +1,182 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// clusterTestSchema returns schema which has 2 triangles (articles-comments-users and billing_*) connected with billing_invoices -> users, and an isolated table (settings)
+func clusterTestSchema() *Schema {
+	return NewSchema([]*Table{
+		{
+			Name: "articles",
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+			},
+		},
+		{
+			Name: "billing_customers",
+		},
+		{
+			Name: "billing_invoices",
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "customer_id", ToTable: "billing_customers", ToColumn: "id"},
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+			},
+		},
+		{
+			Name: "billing_payments",
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "customer_id", ToTable: "billing_customers", ToColumn: "id"},
+				{FromColumn: "invoice_id", ToTable: "billing_invoices", ToColumn: "id"},
+			},
+		},
+		{
+			Name: "comments",
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "article_id", ToTable: "articles", ToColumn: "id"},
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+				{FromColumn: "parent_id", ToTable: "comments", ToColumn: "id"},
+			},
+		},
+		{
+			Name: "settings",
+		},
+		{
+			Name: "users",
+		},
+	})
+}
+
+func clusterTableNames(clusters []*Cluster) map[string][]string {
+	names := map[string][]string{}
+	for _, cluster := range clusters {
+		for _, table := range cluster.Tables {
+			names[cluster.Name] = append(names[cluster.Name], table.Name)
+		}
+	}
+	return names
+}
+
+func TestSchema_SplitByComponent(t *testing.T) {
+	clusters := clusterTestSchema().SplitByComponent()
+
+	if assert.Len(t, clusters, 2) {
+		// users and billing_invoices have the same number of relations, so the former in schema is used
+		assert.Equal(t, "billing_invoices", clusters[0].Name)
+		assert.Equal(t, IsolatedClusterName, clusters[1].Name)
+	}
+	assert.Equal(t, map[string][]string{
+		"billing_invoices": {"articles", "billing_customers", "billing_invoices", "billing_payments", "comments", "users"},
+		"isolated":         {"settings"},
+	}, clusterTableNames(clusters))
+}
+
+func TestSchema_SplitByCommunity(t *testing.T) {
+	clusters := clusterTestSchema().SplitByCommunity()
+
+	assert.Equal(t, map[string][]string{
+		"users":            {"articles", "comments", "users"},
+		"billing_invoices": {"billing_customers", "billing_invoices", "billing_payments"},
+		"isolated":         {"settings"},
+	}, clusterTableNames(clusters))
+	assert.Equal(t, IsolatedClusterName, clusters[len(clusters)-1].Name)
+}
+
+func TestSchema_SplitByComponent_withReservedName(t *testing.T) {
+	s := NewSchema([]*Table{
+		{
+			Name: "isolated",
+		},
+		{
+			Name: "items",
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "isolated_id", ToTable: "isolated", ToColumn: "id"},
+			},
+		},
+		{
+			Name: "settings",
+		},
+	})
+
+	assert.Equal(t, map[string][]string{
+		"isolated_2": {"isolated", "items"},
+		"isolated":   {"settings"},
+	}, clusterTableNames(s.SplitByComponent()))
+}
+
+func TestUniqueClusterNames(t *testing.T) {
+	clusters := []*Cluster{
+		{Name: "index"},
+		{Name: "users"},
+		{Name: "Users"},
+		{Name: "index_2"},
+	}
+
+	got := UniqueClusterNames(clusters, "index")
+
+	var names []string
+	for _, cluster := range got {
+		names = append(names, cluster.Name)
+	}
+	assert.Equal(t, []string{"index_2", "users", "Users_2", "index_2_2"}, names)
+
+	// original clusters aren't modified
+	assert.Equal(t, "index", clusters[0].Name)
+}
+
+func TestSchema_ClusterIndex(t *testing.T) {
+	s := clusterTestSchema()
+	index := s.ClusterIndex(s.SplitByCommunity())
+
+	want := `entity users {
+  articles : table
+  comments : table
+  users : table
+}
+
+entity billing_invoices {
+  billing_customers : table
+  billing_invoices : table
+  billing_payments : table
+}
+
+entity isolated {
+  settings : table
+}
+
+billing_invoices }-- users`
+
+	got := NewSchema([]*Table{index.findTable("users"), index.findTable("billing_invoices"), index.findTable("isolated")}).ToErd(DiagramOption{})
+	assert.Equal(t, want, got)
+}
+
+func Test_louvain(t *testing.T) {
+	t.Run("without edges", func(t *testing.T) {
+		weights := []map[int]float64{{}, {}}
+		assert.Equal(t, []int{0, 1}, louvain(weights))
+	})
+
+	t.Run("2 cliques which are connected with an edge", func(t *testing.T) {
+		weights := []map[int]float64{
+			{1: 1, 2: 1, 3: 1},
+			{0: 1, 2: 1, 3: 1},
+			{0: 1, 1: 1, 3: 1},
+			{0: 1, 1: 1, 2: 1, 4: 1},
+			{3: 1, 5: 1, 6: 1, 7: 1},
+			{4: 1, 6: 1, 7: 1},
+			{4: 1, 5: 1, 7: 1},
+			{4: 1, 5: 1, 6: 1},
+		}
+		got := louvain(weights)
+
+		assert.Equal(t, got[0], got[1])
+		assert.Equal(t, got[0], got[2])
+		assert.Equal(t, got[0], got[3])
+		assert.Equal(t, got[4], got[5])
+		assert.Equal(t, got[4], got[6])
+		assert.Equal(t, got[4], got[7])
+		assert.NotEqual(t, got[0], got[4])
+	})
+}
@@ -11,6 +11,15 @@ type Column struct {
 	Type       string
 	NotNull    bool
 	PrimaryKey bool
+
+	// Default represents default value expression (e.g. 'draft', 0, now()). This is empty when column doesn't have default value
+	Default string
+
+	// Comment represents column comment
+	Comment string
+
+	// SampleValues represents distinct values of column which are sampled from database (e.g. Article and Photo of commentable_type). This is nil when values aren't sampled
+	SampleValues []string
 }
 
 // ToErd returns ERD formatted column
@@ -38,3 +47,17 @@ func (c *Column) ToMermaid() string {
 
 	return fmt.Sprintf("%s %s", mermaidType, c.Name)
 }
+
+// ToD2 returns D2 formatted column
+func (c *Column) ToD2(constraints []string) string {
+	str := fmt.Sprintf("%s: %s", d2Key(c.Name), d2String(c.Type))
+
+	switch len(constraints) {
+	case 0:
+		return str
+	case 1:
+		return fmt.Sprintf("%s {constraint: %s}", str, constraints[0])
+	}
+
+	return fmt.Sprintf("%s {constraint: [%s]}", str, strings.Join(constraints, "; "))
+}
@@ -49,3 +49,46 @@ func TestColumn_ToErd(t *testing.T) {
 		})
 	}
 }
+
+func TestColumn_ToD2(t *testing.T) {
+	type args struct {
+		constraints []string
+	}
+	tests := []struct {
+		name   string
+		column *Column
+		args   args
+		want   string
+	}{
+		{
+			name:   "without constraints",
+			column: &Column{Name: "name", Type: "text"},
+			args:   args{},
+			want:   `name: "text"`,
+		},
+		{
+			name:   "with a constraint",
+			column: &Column{Name: "id", Type: "integer", PrimaryKey: true},
+			args:   args{constraints: []string{"primary_key"}},
+			want:   `id: "integer" {constraint: primary_key}`,
+		},
+		{
+			name:   "with constraints",
+			column: &Column{Name: "user_id", Type: "integer"},
+			args:   args{constraints: []string{"foreign_key", "unique"}},
+			want:   `user_id: "integer" {constraint: [foreign_key; unique]}`,
+		},
+		{
+			name:   "with reserved keyword and special characters",
+			column: &Column{Name: "label", Type: "text[]"},
+			args:   args{},
+			want:   `"label": "text[]"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.column.ToD2(tt.args.constraints)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
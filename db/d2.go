@@ -0,0 +1,45 @@
+package db
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var d2IdentifierRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// d2ReservedKeywords represents D2 keywords which cannot be used as key without quotes
+var d2ReservedKeywords = map[string]bool{
+	"label":      true,
+	"shape":      true,
+	"style":      true,
+	"constraint": true,
+	"icon":       true,
+	"near":       true,
+	"width":      true,
+	"height":     true,
+	"tooltip":    true,
+	"link":       true,
+	"direction":  true,
+	"class":      true,
+	"vars":       true,
+	"classes":    true,
+	"grid-rows":  true,
+	"top":        true,
+	"left":       true,
+}
+
+// d2Key returns D2 key. key is quoted when it contains special characters or is reserved keyword
+func d2Key(key string) string {
+	if d2IdentifierRe.MatchString(key) && !d2ReservedKeywords[strings.ToLower(key)] {
+		return key
+	}
+	return d2String(key)
+}
+
+// d2String returns double-quoted D2 string
+func d2String(str string) string {
+	str = strings.ReplaceAll(str, `\`, `\\`)
+	str = strings.ReplaceAll(str, `"`, `\"`)
+	return fmt.Sprintf(`"%s"`, str)
+}
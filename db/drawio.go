@@ -0,0 +1,143 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	drawioHeaderHeight = 30
+	drawioRowHeight    = 26
+	drawioKeyWidth     = 40
+	drawioMinWidth     = 160
+	drawioCharWidth    = 7
+
+	drawioTableStyle  = "shape=table;startSize=30;container=1;collapsible=1;childLayout=tableLayout;fixedRows=1;rowLines=0;fontStyle=1;align=center;resizeLast=1;html=1;"
+	drawioRowStyle    = "shape=tableRow;horizontal=0;startSize=0;swimlaneHead=0;swimlaneBody=0;fillColor=none;collapsible=0;dropTarget=0;points=[[0,0.5],[1,0.5]];portConstraint=eastwest;top=0;left=0;right=0;bottom=0;html=1;"
+	drawioKeyStyle    = "shape=partialRectangle;connectable=0;fillColor=none;top=0;left=0;bottom=0;right=0;editable=1;overflow=hidden;whiteSpace=wrap;html=1;"
+	drawioColumnStyle = "shape=partialRectangle;connectable=0;fillColor=none;top=0;left=0;bottom=0;right=0;align=left;spacingLeft=6;overflow=hidden;whiteSpace=wrap;html=1;"
+	drawioEdgeStyle   = "edgeStyle=entityRelationEdgeStyle;fontSize=12;html=1;endArrow=ERmandOne;startArrow=%s;rounded=0;"
+	drawioTriggerEdge = "edgeStyle=orthogonalEdgeStyle;fontSize=12;html=1;dashed=1;endArrow=open;rounded=0;"
+)
+
+// ToDrawio returns draw.io (diagrams.net) formatted schema
+func (s *Schema) ToDrawio(showTrigger bool) string {
+	lines := []string{
+		`<mxfile host="plant_erd">`,
+		`  <diagram id="erd" name="ERD">`,
+		`    <mxGraphModel grid="1" gridSize="10" guides="1" tooltips="1" connect="1" arrows="1" fold="1" page="0" pageScale="1" math="0" shadow="0">`,
+		`      <root>`,
+		`        <mxCell id="0" />`,
+		`        <mxCell id="1" parent="0" />`,
+	}
+
+	resolver := NewTableResolver(s.Tables)
+
+	// cell ids of tables and columns
+	tableIDs := map[string]string{}
+	columnIDs := map[string]string{}
+
+	for i, box := range layoutTables(s.Tables, drawioTableSize) {
+		tableID := fmt.Sprintf("table-%d", i)
+		tableIDs[box.table.Name] = tableID
+
+		lines = append(lines, fmt.Sprintf(`        <mxCell id="%s" value="%s" style="%s" vertex="1" parent="1">`, tableID, xmlEscape(box.table.Name), drawioTableStyle))
+		lines = append(lines, fmt.Sprintf(`          <mxGeometry x="%g" y="%g" width="%g" height="%g" as="geometry" />`, box.x, box.y, box.width, box.height))
+		lines = append(lines, `        </mxCell>`)
+
+		for j, column := range box.table.Columns {
+			rowID := fmt.Sprintf("%s-column-%d", tableID, j)
+			columnIDs[box.table.Name+"."+column.Name] = rowID
+
+			y := drawioHeaderHeight + j*drawioRowHeight
+			lines = append(lines,
+				fmt.Sprintf(`        <mxCell id="%s" value="" style="%s" vertex="1" parent="%s">`, rowID, drawioRowStyle, tableID),
+				fmt.Sprintf(`          <mxGeometry y="%d" width="%g" height="%d" as="geometry" />`, y, box.width, drawioRowHeight),
+				`        </mxCell>`,
+				fmt.Sprintf(`        <mxCell id="%s-key" value="%s" style="%s" vertex="1" parent="%s">`, rowID, box.table.columnKey(column), drawioKeyStyle, rowID),
+				fmt.Sprintf(`          <mxGeometry width="%d" height="%d" as="geometry" />`, drawioKeyWidth, drawioRowHeight),
+				`        </mxCell>`,
+				fmt.Sprintf(`        <mxCell id="%s-name" value="%s" style="%s" vertex="1" parent="%s">`, rowID, xmlEscape(drawioColumnLabel(column)), drawioColumnStyle, rowID),
+				fmt.Sprintf(`          <mxGeometry x="%d" width="%g" height="%d" as="geometry" />`, drawioKeyWidth, box.width-drawioKeyWidth, drawioRowHeight),
+				`        </mxCell>`,
+			)
+		}
+	}
+
+	for i, table := range s.Tables {
+		for j, foreignKey := range table.ForeignKeys {
+			toTable := strings.ToLower(foreignKey.ToTable)
+			if resolved := resolver.Find(foreignKey.ToTable); resolved != nil {
+				toTable = resolved.Name
+			}
+			target, ok := columnIDs[toTable+"."+foreignKey.ToColumn]
+			if !ok {
+				target, ok = tableIDs[toTable]
+			}
+			if !ok {
+				continue
+			}
+
+			source, ok := columnIDs[table.Name+"."+foreignKey.FromColumn]
+			if !ok {
+				source = tableIDs[table.Name]
+			}
+
+			startArrow := "ERzeroToMany"
+			if column := table.findColumn(foreignKey.FromColumn); column != nil && column.NotNull {
+				startArrow = "ERoneToMany"
+			}
+
+			style := fmt.Sprintf(drawioEdgeStyle, startArrow)
+			if foreignKey.Inferred {
+				style += "dashed=1;"
+			}
+
+			lines = append(lines,
+				fmt.Sprintf(`        <mxCell id="table-%d-fk-%d" value="" style="%s" edge="1" parent="1" source="%s" target="%s">`, i, j, style, source, target),
+				`          <mxGeometry relative="1" as="geometry" />`,
+				`        </mxCell>`,
+			)
+		}
+	}
+
+	if showTrigger {
+		for i, relation := range s.triggerRelations() {
+			lines = append(lines,
+				fmt.Sprintf(`        <mxCell id="trigger-%d" value="%s" style="%s" edge="1" parent="1" source="%s" target="%s">`, i, xmlEscape(relation.trigger.Name), drawioTriggerEdge, tableIDs[relation.fromTable], tableIDs[relation.toTable]),
+				`          <mxGeometry relative="1" as="geometry" />`,
+				`        </mxCell>`,
+			)
+		}
+	}
+
+	lines = append(lines,
+		`      </root>`,
+		`    </mxGraphModel>`,
+		`  </diagram>`,
+		`</mxfile>`,
+	)
+
+	return strings.Join(lines, "\n")
+}
+
+// drawioTableSize returns width and height of table shape
+func drawioTableSize(table *Table) (float64, float64) {
+	length := len(table.Name)
+	for _, column := range table.Columns {
+		length = max(length, len(drawioColumnLabel(column)))
+	}
+
+	width := max(drawioMinWidth, drawioKeyWidth+length*drawioCharWidth+20)
+	height := drawioHeaderHeight + len(table.Columns)*drawioRowHeight
+
+	return float64(width), float64(height)
+}
+
+func drawioColumnLabel(column *Column) string {
+	label := fmt.Sprintf("%s : %s", column.Name, column.Type)
+	if column.NotNull && !column.PrimaryKey {
+		label += " NOT NULL"
+	}
+	return label
+}
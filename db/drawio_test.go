@@ -0,0 +1,84 @@
+package db
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type drawioFile struct {
+	Cells []drawioCell `xml:"diagram>mxGraphModel>root>mxCell"`
+}
+
+type drawioCell struct {
+	ID       string `xml:"id,attr"`
+	Value    string `xml:"value,attr"`
+	Style    string `xml:"style,attr"`
+	Parent   string `xml:"parent,attr"`
+	Source   string `xml:"source,attr"`
+	Target   string `xml:"target,attr"`
+	Edge     string `xml:"edge,attr"`
+	Geometry struct {
+		X      float64 `xml:"x,attr"`
+		Y      float64 `xml:"y,attr"`
+		Width  float64 `xml:"width,attr"`
+		Height float64 `xml:"height,attr"`
+	} `xml:"mxGeometry"`
+}
+
+func parseDrawio(t *testing.T, str string) map[string]drawioCell {
+	var file drawioFile
+	err := xml.Unmarshal([]byte(str), &file)
+	require.NoError(t, err)
+
+	cells := map[string]drawioCell{}
+	for _, cell := range file.Cells {
+		cells[cell.ID] = cell
+	}
+	return cells
+}
+
+func TestSchema_ToDrawio(t *testing.T) {
+	s := markdownTestSchema()
+	s.Tables[0].Triggers = []*Trigger{
+		{Name: "touch_<users>", Body: "UPDATE users SET updated_at = now()"},
+	}
+
+	t.Run("tables and foreign keys", func(t *testing.T) {
+		cells := parseDrawio(t, s.ToDrawio(false))
+
+		assert.Equal(t, "articles", cells["table-0"].Value)
+		assert.Equal(t, "users", cells["table-1"].Value)
+
+		// users is referenced by articles, so it is placed on upper layer
+		assert.Less(t, cells["table-1"].Geometry.Y, cells["table-0"].Geometry.Y)
+		assert.InDelta(t, 30+26*3, cells["table-0"].Geometry.Height, 0)
+
+		assert.Equal(t, "table-0", cells["table-0-column-1"].Parent)
+		assert.Equal(t, "PK", cells["table-0-column-0-key"].Value)
+		assert.Equal(t, "FK", cells["table-0-column-1-key"].Value)
+		assert.Equal(t, "user_id : integer NOT NULL", cells["table-0-column-1-name"].Value)
+		assert.Equal(t, "status : text", cells["table-0-column-2-name"].Value)
+
+		edge := cells["table-0-fk-0"]
+		assert.Equal(t, "1", edge.Edge)
+		assert.Equal(t, "table-0-column-1", edge.Source)
+		assert.Equal(t, "table-1-column-0", edge.Target)
+		assert.Contains(t, edge.Style, "startArrow=ERoneToMany")
+		assert.Contains(t, edge.Style, "endArrow=ERmandOne")
+
+		assert.NotContains(t, cells, "trigger-0")
+	})
+
+	t.Run("with trigger", func(t *testing.T) {
+		cells := parseDrawio(t, s.ToDrawio(true))
+
+		edge := cells["trigger-0"]
+		assert.Equal(t, "touch_<users>", edge.Value)
+		assert.Equal(t, "table-0", edge.Source)
+		assert.Equal(t, "table-1", edge.Target)
+		assert.Contains(t, edge.Style, "dashed=1")
+	})
+}
@@ -1,8 +1,97 @@
 package db
 
+import (
+	"fmt"
+	"strings"
+)
+
 // ForeignKey represents foreign key info
 type ForeignKey struct {
 	FromColumn string
 	ToTable    string
 	ToColumn   string
+
+	// Name represents constraint name. This is empty when constraint name isn't available (e.g. SQLite)
+	Name string
+
+	// Inferred represents whether foreign key is inferred from naming convention of column instead of declared constraint (c.f. Schema.InferForeignKeys, Schema.DetectPolymorphicAssociations)
+	Inferred bool
+
+	// Polymorphic represents polymorphic association which foreign key is detected from. This is nil for other foreign keys
+	Polymorphic *PolymorphicAssociation
+}
+
+// RelationOption represents how relations between tables are drawn
+type RelationOption struct {
+	// Label represents label of relation (column, name, join). Relation isn't labelled when this is empty
+	Label string
+
+	// LinkColumn represents whether relation connects columns instead of tables (PlantUML only)
+	LinkColumn bool
+}
+
+// relationLabel returns label of relation which consists of foreignKeys
+func (o RelationOption) relationLabel(foreignKeys []*ForeignKey) string {
+	// Polymorphic relation is always labelled because it cannot be distinguished from others by columns
+	if polymorphic := foreignKeys[0].Polymorphic; polymorphic != nil {
+		switch o.Label {
+		case "":
+			return polymorphic.Name
+		case "join":
+			if polymorphic.Type != "" {
+				return fmt.Sprintf("%s = %s.%s AND %s = '%s'", foreignKeys[0].FromColumn, strings.ToLower(foreignKeys[0].ToTable), foreignKeys[0].ToColumn, polymorphic.TypeColumn, polymorphic.Type)
+			}
+		}
+	}
+
+	switch o.Label {
+	case "name":
+		if foreignKeys[0].Name != "" {
+			return foreignKeys[0].Name
+		}
+	case "column":
+	case "join":
+		var conditions []string
+		for _, foreignKey := range foreignKeys {
+			conditions = append(conditions, fmt.Sprintf("%s = %s.%s", foreignKey.FromColumn, strings.ToLower(foreignKey.ToTable), foreignKey.ToColumn))
+		}
+		return strings.Join(conditions, " AND ")
+	default:
+		return ""
+	}
+
+	var columns []string
+	for _, foreignKey := range foreignKeys {
+		columns = append(columns, foreignKey.FromColumn)
+	}
+	return strings.Join(columns, ", ")
+}
+
+// ForeignKeyConstraints returns foreign keys which are grouped by constraint. Each group has all columns of composite foreign key
+func (t *Table) ForeignKeyConstraints() [][]*ForeignKey {
+	return groupForeignKeys(t.ForeignKeys)
+}
+
+// groupForeignKeys groups foreign keys of composite foreign key constraint. Foreign keys without name aren't grouped
+func groupForeignKeys(foreignKeys []*ForeignKey) [][]*ForeignKey {
+	var groups [][]*ForeignKey
+	indexes := map[string]int{}
+
+	for _, foreignKey := range foreignKeys {
+		if foreignKey.Name == "" {
+			groups = append(groups, []*ForeignKey{foreignKey})
+			continue
+		}
+
+		key := foreignKey.Name + "\x00" + strings.ToLower(foreignKey.ToTable)
+		if i, ok := indexes[key]; ok {
+			groups[i] = append(groups[i], foreignKey)
+			continue
+		}
+
+		indexes[key] = len(groups)
+		groups = append(groups, []*ForeignKey{foreignKey})
+	}
+
+	return groups
 }
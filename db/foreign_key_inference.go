@@ -0,0 +1,106 @@
+package db
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultForeignKeyPattern represents default pattern of InferForeignKeys
+const DefaultForeignKeyPattern = "{table}_id=id"
+
+// foreignKeyPattern represents parsed pattern of InferForeignKeys
+type foreignKeyPattern struct {
+	columnRe         *regexp.Regexp
+	referencedColumn string
+}
+
+// parseForeignKeyPattern parses pattern as "COLUMN=REFERENCED_COLUMN" (e.g. {table}_id=id). REFERENCED_COLUMN is id when it is omitted
+func parseForeignKeyPattern(pattern string) (*foreignKeyPattern, error) {
+	column, referencedColumn, found := strings.Cut(pattern, "=")
+	if !found {
+		referencedColumn = "id"
+	}
+
+	before, after, found := strings.Cut(column, "{table}")
+	if !found || strings.Contains(after, "{table}") || referencedColumn == "" {
+		return nil, fmt.Errorf("%s is invalid foreign key pattern. Pattern must be COLUMN=REFERENCED_COLUMN and COLUMN must contain {table} once (e.g. %s)", pattern, DefaultForeignKeyPattern)
+	}
+
+	columnRe := regexp.MustCompile("(?i)^" + regexp.QuoteMeta(before) + "(.+)" + regexp.QuoteMeta(after) + "$")
+
+	return &foreignKeyPattern{columnRe: columnRe, referencedColumn: referencedColumn}, nil
+}
+
+// InferForeignKeys returns schema which has foreign keys inferred from naming convention of columns in addition to declared foreign keys.
+// {table} of pattern matches singular or plural name of referenced table (e.g. {table}_id=id infers articles.user_id -> users.id).
+// Inferred foreign keys are marked with ForeignKey.Inferred
+func (s *Schema) InferForeignKeys(patterns []string) (*Schema, error) {
+	if len(patterns) == 0 {
+		patterns = []string{DefaultForeignKeyPattern}
+	}
+
+	var parsedPatterns []*foreignKeyPattern
+	for _, pattern := range patterns {
+		parsed, err := parseForeignKeyPattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		parsedPatterns = append(parsedPatterns, parsed)
+	}
+
+	resolver := NewTableResolver(s.Tables)
+
+	var tables []*Table
+	for _, table := range s.Tables {
+		copied := *table
+		copied.ForeignKeys = append([]*ForeignKey(nil), table.ForeignKeys...)
+
+		declaredColumns := map[string]bool{}
+		for _, foreignKey := range table.ForeignKeys {
+			declaredColumns[foreignKey.FromColumn] = true
+		}
+
+		for _, column := range table.Columns {
+			if declaredColumns[column.Name] {
+				continue
+			}
+
+			if foreignKey := inferForeignKey(&copied, column, parsedPatterns, resolver); foreignKey != nil {
+				copied.ForeignKeys = append(copied.ForeignKeys, foreignKey)
+			}
+		}
+
+		tables = append(tables, &copied)
+	}
+
+	return NewSchema(tables), nil
+}
+
+// inferForeignKey returns foreign key of column which matches with the first pattern. This returns nil when referenced table or column isn't found
+func inferForeignKey(table *Table, column *Column, patterns []*foreignKeyPattern, resolver *TableResolver) *ForeignKey {
+	for _, pattern := range patterns {
+		matches := pattern.columnRe.FindStringSubmatch(column.Name)
+		if matches == nil {
+			continue
+		}
+
+		// Prefer table in the same schema (e.g. public.articles.user_id -> public.users.id)
+		name := strings.ToLower(matches[1])
+		for _, toTable := range resolver.findNear(table, []string{Pluralize(name), name, Singularize(name)}) {
+			// Column which is named after its own table is regarded as its identifier (e.g. users.user_id)
+			if toTable.Name == table.Name {
+				continue
+			}
+
+			toColumn := toTable.FindColumnIgnoreCase(pattern.referencedColumn)
+			if toColumn == nil {
+				continue
+			}
+
+			return &ForeignKey{FromColumn: column.Name, ToTable: toTable.Name, ToColumn: toColumn.Name, Inferred: true}
+		}
+	}
+
+	return nil
+}
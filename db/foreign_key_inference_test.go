@@ -0,0 +1,179 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func inferenceTestSchema() *Schema {
+	return NewSchema([]*Table{
+		{
+			Name: "articles",
+			Columns: []*Column{
+				{Name: "id", Type: "integer", PrimaryKey: true},
+				{Name: "user_id", Type: "integer"},
+				{Name: "editor_id", Type: "integer"},
+				{Name: "category_code", Type: "varchar(10)"},
+				{Name: "person_id", Type: "integer"},
+				{Name: "reviewer_id", Type: "integer"},
+			},
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "editor_id", ToTable: "users", ToColumn: "id"},
+			},
+		},
+		{
+			Name: "categories",
+			Columns: []*Column{
+				{Name: "code", Type: "varchar(10)", PrimaryKey: true},
+			},
+		},
+		{
+			Name: "people",
+			Columns: []*Column{
+				{Name: "id", Type: "integer", PrimaryKey: true},
+			},
+		},
+		{
+			Name: "users",
+			Columns: []*Column{
+				{Name: "id", Type: "integer", PrimaryKey: true},
+				{Name: "user_id", Type: "integer"},
+			},
+		},
+	})
+}
+
+func TestSchema_InferForeignKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		want     map[string][]*ForeignKey
+	}{
+		{
+			name:     "default pattern",
+			patterns: nil,
+			want: map[string][]*ForeignKey{
+				"articles": {
+					{FromColumn: "editor_id", ToTable: "users", ToColumn: "id"},
+					{FromColumn: "user_id", ToTable: "users", ToColumn: "id", Inferred: true},
+					{FromColumn: "person_id", ToTable: "people", ToColumn: "id", Inferred: true},
+				},
+				"categories": nil,
+				"people":     nil,
+				"users":      nil,
+			},
+		},
+		{
+			name:     "custom patterns",
+			patterns: []string{"{table}_code=code", "{table}_id"},
+			want: map[string][]*ForeignKey{
+				"articles": {
+					{FromColumn: "editor_id", ToTable: "users", ToColumn: "id"},
+					{FromColumn: "user_id", ToTable: "users", ToColumn: "id", Inferred: true},
+					{FromColumn: "category_code", ToTable: "categories", ToColumn: "code", Inferred: true},
+					{FromColumn: "person_id", ToTable: "people", ToColumn: "id", Inferred: true},
+				},
+				"categories": nil,
+				"people":     nil,
+				"users":      nil,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := inferenceTestSchema()
+			got, err := s.InferForeignKeys(tt.patterns)
+			require.NoError(t, err)
+
+			foreignKeys := map[string][]*ForeignKey{}
+			for _, table := range got.Tables {
+				foreignKeys[table.Name] = table.ForeignKeys
+			}
+			assert.Equal(t, tt.want, foreignKeys)
+
+			// original schema isn't modified
+			assert.Len(t, s.findTable("articles").ForeignKeys, 1)
+		})
+	}
+}
+
+func TestSchema_InferForeignKeys_withSchemaName(t *testing.T) {
+	s := NewSchema([]*Table{
+		{
+			Name:    "billing.invoices",
+			Columns: []*Column{{Name: "user_id", Type: "integer"}},
+		},
+		{
+			Name:    "billing.users",
+			Columns: []*Column{{Name: "id", Type: "integer", PrimaryKey: true}},
+		},
+		{
+			Name:    "users",
+			Columns: []*Column{{Name: "id", Type: "integer", PrimaryKey: true}},
+		},
+	})
+
+	got, err := s.InferForeignKeys(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*ForeignKey{
+		{FromColumn: "user_id", ToTable: "billing.users", ToColumn: "id", Inferred: true},
+	}, got.Tables[0].ForeignKeys)
+}
+
+func TestSchema_InferForeignKeys_withUncountableSuffix(t *testing.T) {
+	s := NewSchema([]*Table{
+		{
+			Name:    "line_items",
+			Columns: []*Column{{Name: "price_id", Type: "integer"}},
+		},
+		{
+			Name:    "prices",
+			Columns: []*Column{{Name: "id", Type: "integer", PrimaryKey: true}},
+		},
+	})
+
+	got, err := s.InferForeignKeys(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []*ForeignKey{
+		{FromColumn: "price_id", ToTable: "prices", ToColumn: "id", Inferred: true},
+	}, got.Tables[0].ForeignKeys)
+}
+
+func TestSchema_InferForeignKeys_withInvalidPattern(t *testing.T) {
+	tests := []string{"user_id=id", "{table}_{table}=id", "{table}_id="}
+	for _, pattern := range tests {
+		t.Run(pattern, func(t *testing.T) {
+			_, err := inferenceTestSchema().InferForeignKeys([]string{pattern})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), pattern+" is invalid foreign key pattern")
+		})
+	}
+}
+
+func TestSchema_ToErd_withInferredForeignKey(t *testing.T) {
+	s := NewSchema([]*Table{
+		{
+			Name:    "articles",
+			Columns: []*Column{{Name: "user_id", Type: "integer"}},
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "id", Inferred: true},
+			},
+		},
+		{
+			Name:    "users",
+			Columns: []*Column{{Name: "id", Type: "integer", PrimaryKey: true}},
+		},
+	})
+
+	assert.Contains(t, s.ToErd(DiagramOption{}), "articles }.. users")
+	assert.Contains(t, s.ToMermaid(DiagramOption{}), "users ||..o{ articles : owns")
+	assert.Contains(t, s.ToClassDiagram(DiagramOption{}), `articles "0..*" ..> "0..1" users : user_id`)
+	assert.Contains(t, s.ToD2(false), "articles.user_id -> users.id {style.stroke-dash: 3}")
+	assert.Contains(t, s.ToSVG(false), `<path class="relation inferred"`)
+	assert.Contains(t, s.ToDrawio(false), "startArrow=ERzeroToMany;rounded=0;dashed=1;")
+	assert.Contains(t, s.ToMarkdown(false), "* `user_id` → [users](#users) (`id`) (inferred)")
+}
@@ -0,0 +1,47 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelationOption_relationLabel(t *testing.T) {
+	composite := []*ForeignKey{
+		{FromColumn: "order_id", ToTable: "order_items", ToColumn: "order_id", Name: "fk_order_items"},
+		{FromColumn: "item_no", ToTable: "order_items", ToColumn: "item_no", Name: "fk_order_items"},
+	}
+	unnamed := []*ForeignKey{
+		{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+	}
+
+	tests := []struct {
+		name        string
+		label       string
+		foreignKeys []*ForeignKey
+		want        string
+	}{
+		{name: "no label", label: "", foreignKeys: composite, want: ""},
+		{name: "column", label: "column", foreignKeys: composite, want: "order_id, item_no"},
+		{name: "name", label: "name", foreignKeys: composite, want: "fk_order_items"},
+		{name: "name without constraint name", label: "name", foreignKeys: unnamed, want: "user_id"},
+		{name: "join", label: "join", foreignKeys: composite, want: "order_id = order_items.order_id AND item_no = order_items.item_no"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := RelationOption{Label: tt.label}
+			assert.Equal(t, tt.want, o.relationLabel(tt.foreignKeys))
+		})
+	}
+}
+
+func Test_groupForeignKeys(t *testing.T) {
+	fk1 := &ForeignKey{FromColumn: "order_id", ToTable: "order_items", ToColumn: "order_id", Name: "fk_order_items"}
+	fk2 := &ForeignKey{FromColumn: "user_id", ToTable: "users", ToColumn: "id"}
+	fk3 := &ForeignKey{FromColumn: "item_no", ToTable: "order_items", ToColumn: "item_no", Name: "fk_order_items"}
+	fk4 := &ForeignKey{FromColumn: "seller_id", ToTable: "users", ToColumn: "id"}
+
+	got := groupForeignKeys([]*ForeignKey{fk1, fk2, fk3, fk4})
+
+	assert.Equal(t, [][]*ForeignKey{{fk1, fk3}, {fk2}, {fk4}}, got)
+}
@@ -0,0 +1,103 @@
+package db
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// plantUmlGroupNameRe represents group name which can be written without quotes in PlantUML
+var plantUmlGroupNameRe = regexp.MustCompile(`^[\w.]+$`)
+
+// plantUmlNamespaceInvalidCharRe represents characters which can't be used in name of PlantUML namespace.
+// Namespace name can't be quoted because it is a part of name of entity (e.g. billing.invoices)
+var plantUmlNamespaceInvalidCharRe = regexp.MustCompile(`[^\w.]`)
+
+// mermaidNamespaceInvalidCharRe represents characters which can't be used in name of Mermaid namespace
+var mermaidNamespaceInvalidCharRe = regexp.MustCompile(`\W`)
+
+// tableGroup represents tables which belong to the same group
+type tableGroup struct {
+	name   string
+	tables []*Table
+}
+
+// tableGroups returns tables grouped by Table.Group. Groups are ordered by first appearance in tables, and tables which don't belong to any group are returned as a group of empty name
+func (s *Schema) tableGroups() []*tableGroup {
+	var groups []*tableGroup
+	groupsByName := map[string]*tableGroup{}
+
+	for _, table := range s.Tables {
+		group, ok := groupsByName[table.Group]
+		if !ok {
+			group = &tableGroup{name: table.Group}
+			groupsByName[table.Group] = group
+			groups = append(groups, group)
+		}
+		group.tables = append(group.tables, table)
+	}
+
+	return groups
+}
+
+// wrapPlantUmlGroup returns PlantUML block (e.g. package, namespace, rectangle) which wraps contents
+func wrapPlantUmlGroup(groupStyle string, groupName string, contents []string) string {
+	if groupStyle == "" {
+		groupStyle = "package"
+	}
+
+	name := groupName
+	if groupStyle == "namespace" {
+		name = plantUmlNamespaceName(groupName)
+	} else if !plantUmlGroupNameRe.MatchString(name) {
+		name = fmt.Sprintf("\"%s\"", name)
+	}
+
+	lines := []string{fmt.Sprintf("%s %s {", groupStyle, name)}
+	for _, content := range contents {
+		lines = append(lines, indent(content))
+	}
+	lines = append(lines, "}")
+
+	return strings.Join(lines, "\n\n")
+}
+
+// plantUmlNamespaceName returns group name which invalid characters are replaced with underscore (e.g. billing-dept -> billing_dept)
+func plantUmlNamespaceName(groupName string) string {
+	return plantUmlNamespaceInvalidCharRe.ReplaceAllString(groupName, "_")
+}
+
+// mermaidNamespaceName returns group name which invalid characters are replaced with underscore (e.g. billing-dept -> billing_dept)
+func mermaidNamespaceName(groupName string) string {
+	return mermaidNamespaceInvalidCharRe.ReplaceAllString(groupName, "_")
+}
+
+// plantUmlEntityNames returns names of entities which are used in relations (table name -> entity name).
+// Entity in namespace is referred with namespace name (e.g. billing.invoices) unless table name already starts with it (e.g. public.users in public)
+func (s *Schema) plantUmlEntityNames(groupStyle string) map[string]string {
+	names := map[string]string{}
+	for _, table := range s.Tables {
+		names[table.Name] = table.Name
+
+		if groupStyle != "namespace" || table.Group == "" {
+			continue
+		}
+
+		namespace := plantUmlNamespaceName(table.Group)
+		if !strings.HasPrefix(table.Name, namespace+".") {
+			names[table.Name] = namespace + "." + table.Name
+		}
+	}
+	return names
+}
+
+// indent returns str which each non-empty line is indented with 2 spaces
+func indent(str string) string {
+	lines := strings.Split(str, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = "  " + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,229 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func groupTestSchema() *Schema {
+	return NewSchema([]*Table{
+		{
+			Name: "billing_invoices",
+			Columns: []*Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+				{Name: "user_id", Type: "integer", NotNull: true},
+			},
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+			},
+			Group: "billing",
+		},
+		{
+			Name: "users",
+			Columns: []*Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+			},
+		},
+		{
+			Name: "billing_payments",
+			Columns: []*Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+			},
+			Group: "billing",
+		},
+	})
+}
+
+func TestSchema_ToErd_withGroup(t *testing.T) {
+	tests := []struct {
+		name       string
+		groupStyle string
+		want       string
+	}{
+		{
+			name:       "default",
+			groupStyle: "",
+			want: `package billing {
+
+  entity billing_invoices {
+    * id : integer
+    --
+    * user_id : integer
+  }
+
+  entity billing_payments {
+    * id : integer
+  }
+
+}
+
+entity users {
+  * id : integer
+}
+
+billing_invoices }-- users`,
+		},
+		{
+			name:       "rectangle",
+			groupStyle: "rectangle",
+			want: `rectangle billing {
+
+  entity billing_invoices {
+    * id : integer
+    --
+    * user_id : integer
+  }
+
+  entity billing_payments {
+    * id : integer
+  }
+
+}
+
+entity users {
+  * id : integer
+}
+
+billing_invoices }-- users`,
+		},
+		{
+			name:       "namespace",
+			groupStyle: "namespace",
+			want: `namespace billing {
+
+  entity billing_invoices {
+    * id : integer
+    --
+    * user_id : integer
+  }
+
+  entity billing_payments {
+    * id : integer
+  }
+
+}
+
+entity users {
+  * id : integer
+}
+
+billing.billing_invoices }-- users`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := groupTestSchema()
+			assert.Equal(t, tt.want, s.ToErd(DiagramOption{GroupStyle: tt.groupStyle}))
+		})
+	}
+}
+
+func TestSchema_ToMermaid_withGroup(t *testing.T) {
+	want := `erDiagram
+
+%% billing
+
+billing_invoices {
+  integer id
+  integer user_id
+}
+
+billing_payments {
+  integer id
+}
+
+users {
+  integer id
+}
+
+users ||--o{ billing_invoices : owns`
+
+	s := groupTestSchema()
+	assert.Equal(t, want, s.ToMermaid(DiagramOption{}))
+}
+
+func TestSchema_ToMermaidClassDiagram_withGroup(t *testing.T) {
+	want := `classDiagram
+
+namespace billing {
+  class billing_invoices {
+    integer id PK
+    integer user_id FK NOT NULL
+  }
+  class billing_payments {
+    integer id PK
+  }
+}
+
+class users {
+  integer id PK
+}
+
+billing_invoices "0..*" --> "1" users : user_id`
+
+	s := groupTestSchema()
+	assert.Equal(t, want, s.ToMermaidClassDiagram(DiagramOption{}))
+}
+
+func TestSchema_ToClassDiagram_withNamespace(t *testing.T) {
+	s := NewSchema([]*Table{
+		{
+			Name:        "public.articles",
+			Columns:     []*Column{{Name: "user_id", Type: "integer", NotNull: true}},
+			ForeignKeys: []*ForeignKey{{FromColumn: "user_id", ToTable: "users", ToColumn: "id"}},
+			Group:       "public",
+		},
+		{
+			Name:  "users",
+			Group: "billing-dept",
+		},
+	})
+
+	got := s.ToClassDiagram(DiagramOption{GroupStyle: "namespace"})
+
+	assert.Contains(t, got, "namespace billing_dept {")
+	assert.Contains(t, got, `public.articles "0..*" --> "1" billing_dept.users : user_id`)
+}
+
+func TestSchema_ToMermaidClassDiagram_withInvalidNamespace(t *testing.T) {
+	s := NewSchema([]*Table{
+		{Name: "invoices", Group: "Billing Dept-2"},
+	})
+
+	assert.Equal(t, "classDiagram\n\nnamespace Billing_Dept_2 {\n  class invoices\n}", s.ToMermaidClassDiagram(DiagramOption{}))
+}
+
+func Test_wrapPlantUmlGroup(t *testing.T) {
+	type args struct {
+		groupStyle string
+		groupName  string
+		contents   []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "namespace",
+			args: args{groupStyle: "namespace", groupName: "public", contents: []string{"entity a {\n}"}},
+			want: "namespace public {\n\n  entity a {\n  }\n\n}",
+		},
+		{
+			name: "namespace which contains space",
+			args: args{groupStyle: "namespace", groupName: "Billing Domain", contents: []string{"entity a {\n}"}},
+			want: "namespace Billing_Domain {\n\n  entity a {\n  }\n\n}",
+		},
+		{
+			name: "name which contains space",
+			args: args{groupStyle: "package", groupName: "Billing Domain", contents: []string{"entity a {\n}"}},
+			want: "package \"Billing Domain\" {\n\n  entity a {\n  }\n\n}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, wrapPlantUmlGroup(tt.args.groupStyle, tt.args.groupName, tt.args.contents))
+		})
+	}
+}
@@ -7,9 +7,108 @@ import (
 
 // Index represents index definition
 type Index struct {
-	Name    string
+	Name string
+
+	// Columns represents column key parts. Expression key parts aren't included (c.f. KeyParts)
 	Columns []string
-	Unique  bool
+
+	Unique bool
+
+	// Method represents access method (e.g. btree, gin, gist, hash, fulltext)
+	Method string
+
+	// KeyParts represents all key parts in order when index has expression (functional) key parts (e.g. lower(name), id).
+	// This is nil when index consists of only columns
+	KeyParts []*IndexKeyPart
+
+	// Predicate represents WHERE clause of partial index
+	Predicate string
+
+	// Orders represents non-default sort order of each key part in order of key parts (e.g. "", "DESC").
+	// Key parts are KeyParts when index has expression key parts, otherwise Columns. This is nil when all key parts are in default order
+	Orders []string
+
+	// Lengths represents prefix length of each key part in order of key parts (e.g. MySQL Sub_part). 0 means whole column
+	Lengths []int
+}
+
+// IndexKeyPart represents key part of index which is either a column or an expression
+type IndexKeyPart struct {
+	Column     string
+	Expression string
+}
+
+// AddColumn appends column key part
+func (i *Index) AddColumn(column string) {
+	i.Columns = append(i.Columns, column)
+
+	if i.KeyParts != nil {
+		i.KeyParts = append(i.KeyParts, &IndexKeyPart{Column: column})
+	}
+}
+
+// AddExpression appends expression key part
+func (i *Index) AddExpression(expression string) {
+	if i.KeyParts == nil {
+		i.KeyParts = []*IndexKeyPart{}
+		for _, column := range i.Columns {
+			i.KeyParts = append(i.KeyParts, &IndexKeyPart{Column: column})
+		}
+	}
+
+	i.KeyParts = append(i.KeyParts, &IndexKeyPart{Expression: expression})
+}
+
+// SetOrder sets non-default sort order of the last key part (e.g. DESC)
+func (i *Index) SetOrder(order string) {
+	n := i.keyPartCount()
+	for len(i.Orders) < n {
+		i.Orders = append(i.Orders, "")
+	}
+	i.Orders[n-1] = order
+}
+
+// SetLength sets prefix length of the last key part
+func (i *Index) SetLength(length int) {
+	n := i.keyPartCount()
+	for len(i.Lengths) < n {
+		i.Lengths = append(i.Lengths, 0)
+	}
+	i.Lengths[n-1] = length
+}
+
+// Order returns non-default sort order of n-th key part. This returns empty string when key part is in default order
+func (i *Index) Order(n int) string {
+	if n < len(i.Orders) {
+		return i.Orders[n]
+	}
+	return ""
+}
+
+// Length returns prefix length of n-th key part. This returns 0 when key part isn't prefix
+func (i *Index) Length(n int) int {
+	if n < len(i.Lengths) {
+		return i.Lengths[n]
+	}
+	return 0
+}
+
+func (i *Index) keyPartCount() int {
+	if i.KeyParts != nil {
+		return len(i.KeyParts)
+	}
+	return len(i.Columns)
+}
+
+// Expressions returns expression key parts
+func (i *Index) Expressions() []string {
+	var expressions []string
+	for _, keyPart := range i.KeyParts {
+		if keyPart.Expression != "" {
+			expressions = append(expressions, keyPart.Expression)
+		}
+	}
+	return expressions
 }
 
 // ToErd returns ERD formatted index
@@ -20,7 +119,46 @@ func (i *Index) ToErd() string {
 		str += "- "
 	}
 
-	str += fmt.Sprintf("%s (%s)", i.Name, strings.Join(i.Columns, ", "))
+	return str + fmt.Sprintf("%s %s", i.Name, i.Definition())
+}
+
+// Definition returns key parts, method and predicate of index (e.g. (name, created_at DESC) USING gin WHERE deleted_at IS NULL)
+func (i *Index) Definition() string {
+	keyParts := i.KeyParts
+	if keyParts == nil {
+		for _, column := range i.Columns {
+			keyParts = append(keyParts, &IndexKeyPart{Column: column})
+		}
+	}
+
+	var parts []string
+	for n, keyPart := range keyParts {
+		part := keyPart.Expression
+		if part == "" {
+			part = keyPart.Column
+		}
+
+		if length := i.Length(n); length > 0 {
+			part += fmt.Sprintf("(%d)", length)
+		}
+
+		if order := i.Order(n); order != "" {
+			part += " " + order
+		}
+
+		parts = append(parts, part)
+	}
+
+	str := fmt.Sprintf("(%s)", strings.Join(parts, ", "))
+
+	// btree is the default method in most databases
+	if i.Method != "" && i.Method != "btree" {
+		str += " USING " + i.Method
+	}
+
+	if i.Predicate != "" {
+		str += " WHERE " + i.Predicate
+	}
 
 	return str
 }
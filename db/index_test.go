@@ -0,0 +1,126 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex_ToErd(t *testing.T) {
+	tests := []struct {
+		name  string
+		index *Index
+		want  string
+	}{
+		{
+			name: "non unique index",
+			index: &Index{
+				Name:    "index_user_id_on_articles",
+				Columns: []string{"user_id"},
+				Method:  "btree",
+			},
+			want: "index_user_id_on_articles (user_id)",
+		},
+		{
+			name: "unique index",
+			index: &Index{
+				Name:    "index_user_id_and_target_user_id_on_followers",
+				Columns: []string{"user_id", "target_user_id"},
+				Unique:  true,
+			},
+			want: "- index_user_id_and_target_user_id_on_followers (user_id, target_user_id)",
+		},
+		{
+			name: "with sort order and prefix length",
+			index: &Index{
+				Name:    "index_name_and_created_at_on_users",
+				Columns: []string{"name", "created_at"},
+				Orders:  []string{"", "DESC"},
+				Lengths: []int{10},
+			},
+			want: "index_name_and_created_at_on_users (name(10), created_at DESC)",
+		},
+		{
+			name: "with expression, method and predicate",
+			index: &Index{
+				Name:      "index_lower_email_on_users",
+				KeyParts:  []*IndexKeyPart{{Expression: "lower(email)"}},
+				Unique:    true,
+				Method:    "hash",
+				Predicate: "(deleted_at IS NULL)",
+			},
+			want: "- index_lower_email_on_users (lower(email)) USING hash WHERE (deleted_at IS NULL)",
+		},
+		{
+			name: "expression between columns",
+			index: &Index{
+				Name: "index_on_users",
+				KeyParts: []*IndexKeyPart{
+					{Column: "account_id"},
+					{Expression: "lower(email)"},
+					{Column: "created_at"},
+				},
+				Orders: []string{"", "DESC", "DESC"},
+			},
+			want: "index_on_users (account_id, lower(email) DESC, created_at DESC)",
+		},
+		{
+			name: "repeated column",
+			index: &Index{
+				Name:    "index_name_on_users",
+				Columns: []string{"name", "name"},
+				Orders:  []string{"DESC"},
+				Lengths: []int{0, 10},
+			},
+			want: "index_name_on_users (name DESC, name(10))",
+		},
+		{
+			name: "fulltext index",
+			index: &Index{
+				Name:    "index_body_on_articles",
+				Columns: []string{"body"},
+				Method:  "fulltext",
+			},
+			want: "index_body_on_articles (body) USING fulltext",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.index.ToErd()
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestIndex_AddColumn(t *testing.T) {
+	index := &Index{Name: "index_on_users"}
+	index.AddColumn("name")
+	index.SetLength(10)
+	index.AddColumn("created_at")
+	index.SetOrder("DESC")
+
+	assert.Equal(t, &Index{
+		Name:    "index_on_users",
+		Columns: []string{"name", "created_at"},
+		Orders:  []string{"", "DESC"},
+		Lengths: []int{10},
+	}, index)
+}
+
+func TestIndex_AddExpression(t *testing.T) {
+	index := &Index{Name: "index_on_users"}
+	index.AddColumn("account_id")
+	index.SetOrder("DESC")
+	index.AddExpression("lower(email)")
+	index.SetOrder("DESC")
+	index.AddColumn("created_at")
+
+	assert.Equal(t, &Index{
+		Name:     "index_on_users",
+		Columns:  []string{"account_id", "created_at"},
+		KeyParts: []*IndexKeyPart{{Column: "account_id"}, {Expression: "lower(email)"}, {Column: "created_at"}},
+		Orders:   []string{"DESC", "DESC"},
+	}, index)
+	assert.Equal(t, "", index.Order(2))
+	assert.Equal(t, "index_on_users (account_id DESC, lower(email) DESC, created_at)", index.ToErd())
+}
@@ -0,0 +1,121 @@
+package db
+
+import (
+	"regexp"
+	"strings"
+)
+
+type inflection struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+// c.f. https://github.com/rails/rails/blob/v6.0.1/activesupport/lib/active_support/inflections.rb
+var singularInflections = []inflection{
+	{regexp.MustCompile(`(?i)(quiz)zes$`), "${1}"},
+	{regexp.MustCompile(`(?i)(matr)ices$`), "${1}ix"},
+	{regexp.MustCompile(`(?i)(vert|ind)ices$`), "${1}ex"},
+	{regexp.MustCompile(`(?i)(alias|status)(es)?$`), "${1}"},
+	{regexp.MustCompile(`(?i)(octop|vir)(us|i)$`), "${1}us"},
+	{regexp.MustCompile(`(?i)(cris|test)(is|es)$`), "${1}is"},
+	{regexp.MustCompile(`(?i)(shoe)s$`), "${1}"},
+	{regexp.MustCompile(`(?i)(o)es$`), "${1}"},
+	{regexp.MustCompile(`(?i)(bus)(es)?$`), "${1}"},
+	{regexp.MustCompile(`(?i)(x|ch|ss|sh)es$`), "${1}"},
+	{regexp.MustCompile(`(?i)(m)ovies$`), "${1}ovie"},
+	{regexp.MustCompile(`(?i)(s)eries$`), "${1}eries"},
+	{regexp.MustCompile(`(?i)([^aeiouy]|qu)ies$`), "${1}y"},
+	{regexp.MustCompile(`(?i)([lr])ves$`), "${1}f"},
+	{regexp.MustCompile(`(?i)(tive)s$`), "${1}"},
+	{regexp.MustCompile(`(?i)(hive)s$`), "${1}"},
+	{regexp.MustCompile(`(?i)([^f])ves$`), "${1}fe"},
+	{regexp.MustCompile(`(?i)(ss)$`), "${1}"},
+	{regexp.MustCompile(`(?i)s$`), ""},
+}
+
+var pluralInflections = []inflection{
+	{regexp.MustCompile(`(?i)(quiz)$`), "${1}zes"},
+	{regexp.MustCompile(`(?i)(matr|vert|ind)(?:ix|ex)$`), "${1}ices"},
+	{regexp.MustCompile(`(?i)(x|ch|ss|sh)$`), "${1}es"},
+	{regexp.MustCompile(`(?i)([^aeiouy]|qu)y$`), "${1}ies"},
+	{regexp.MustCompile(`(?i)(hive)$`), "${1}s"},
+	{regexp.MustCompile(`(?i)(?:([^f])fe|([lr])f)$`), "${1}${2}ves"},
+	{regexp.MustCompile(`(?i)sis$`), "ses"},
+	{regexp.MustCompile(`(?i)(buffal|tomat)o$`), "${1}oes"},
+	{regexp.MustCompile(`(?i)(bu)s$`), "${1}ses"},
+	{regexp.MustCompile(`(?i)(alias|status)$`), "${1}es"},
+	{regexp.MustCompile(`(?i)(octop|vir)(?:us|i)$`), "${1}i"},
+	{regexp.MustCompile(`(?i)(ax|test)is$`), "${1}es"},
+	{regexp.MustCompile(`(?i)s$`), "s"},
+	{regexp.MustCompile(`$`), "s"},
+}
+
+var irregularInflections = map[string]string{
+	"person": "people",
+	"man":    "men",
+	"child":  "children",
+	"sex":    "sexes",
+	"move":   "moves",
+}
+
+var uncountableWords = []string{"equipment", "information", "rice", "money", "species", "series", "fish", "sheep", "jeans", "police"}
+
+// uncountableInflections matches uncountable words as a whole word (e.g. rice, but not price)
+var uncountableInflections = compileUncountableInflections(uncountableWords)
+
+func compileUncountableInflections(words []string) []*regexp.Regexp {
+	var res []*regexp.Regexp
+	for _, word := range words {
+		// c.f. https://github.com/rails/rails/blob/v6.0.1/activesupport/lib/active_support/inflector/methods.rb#L393
+		res = append(res, regexp.MustCompile(`(?i)\b`+regexp.QuoteMeta(word)+`\z`))
+	}
+	return res
+}
+
+// Singularize returns singular form of word (e.g. users -> user)
+func Singularize(word string) string {
+	if isUncountable(word) {
+		return word
+	}
+
+	for singular, plural := range irregularInflections {
+		if strings.HasSuffix(strings.ToLower(word), plural) {
+			return word[:len(word)-len(plural)] + singular
+		}
+	}
+
+	return applyInflections(word, singularInflections)
+}
+
+// Pluralize returns plural form of word (e.g. user -> users)
+func Pluralize(word string) string {
+	if isUncountable(word) {
+		return word
+	}
+
+	for singular, plural := range irregularInflections {
+		if strings.HasSuffix(strings.ToLower(word), singular) {
+			return word[:len(word)-len(singular)] + plural
+		}
+	}
+
+	return applyInflections(word, pluralInflections)
+}
+
+func isUncountable(word string) bool {
+	for _, re := range uncountableInflections {
+		if re.MatchString(word) {
+			return true
+		}
+	}
+	return false
+}
+
+func applyInflections(word string, inflections []inflection) string {
+	for _, inflection := range inflections {
+		if inflection.re.MatchString(word) {
+			return inflection.re.ReplaceAllString(word, inflection.replacement)
+		}
+	}
+	return word
+}
@@ -0,0 +1,51 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSingularize(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{word: "users", want: "user"},
+		{word: "categories", want: "category"},
+		{word: "addresses", want: "address"},
+		{word: "boxes", want: "box"},
+		{word: "people", want: "person"},
+		{word: "statuses", want: "status"},
+		{word: "series", want: "series"},
+		{word: "prices", want: "price"},
+		{word: "user", want: "user"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			assert.Equal(t, tt.want, Singularize(tt.word))
+		})
+	}
+}
+
+func TestPluralize(t *testing.T) {
+	tests := []struct {
+		word string
+		want string
+	}{
+		{word: "user", want: "users"},
+		{word: "category", want: "categories"},
+		{word: "address", want: "addresses"},
+		{word: "box", want: "boxes"},
+		{word: "person", want: "people"},
+		{word: "status", want: "statuses"},
+		{word: "price", want: "prices"},
+		{word: "Sheep", want: "Sheep"},
+		{word: "users", want: "users"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			assert.Equal(t, tt.want, Pluralize(tt.word))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package db
+
+import (
+	"strings"
+)
+
+const (
+	layoutMarginX = 40
+	layoutMarginY = 40
+	layoutGapX    = 60
+	layoutGapY    = 80
+
+	// layoutMaxWidth represents width which tables in the same layer are wrapped at
+	layoutMaxWidth = 1600
+)
+
+// tableBox represents position and size of table in diagram
+type tableBox struct {
+	table  *Table
+	x      float64
+	y      float64
+	width  float64
+	height float64
+}
+
+// layoutTables places tables on layers. Referenced tables are placed on upper layer than referencing tables,
+// and tables in the same layer are placed from left to right in order of tables (wrapped at layoutMaxWidth).
+// size returns width and height of table
+func layoutTables(tables []*Table, size func(*Table) (float64, float64)) []*tableBox {
+	layers := tableLayers(tables)
+
+	maxLayer := 0
+	for _, layer := range layers {
+		maxLayer = max(maxLayer, layer)
+	}
+
+	boxes := make([]*tableBox, len(tables))
+	y := float64(layoutMarginY)
+
+	for layer := 0; layer <= maxLayer; layer++ {
+		x := float64(layoutMarginX)
+		rowHeight := 0.0
+
+		for i, table := range tables {
+			if layers[table.Name] != layer {
+				continue
+			}
+
+			width, height := size(table)
+
+			if x > layoutMarginX && x+width > layoutMaxWidth {
+				x = layoutMarginX
+				y += rowHeight + layoutGapY
+				rowHeight = 0
+			}
+
+			boxes[i] = &tableBox{table: table, x: x, y: y, width: width, height: height}
+
+			x += width + layoutGapX
+			rowHeight = max(rowHeight, height)
+		}
+
+		if rowHeight > 0 {
+			y += rowHeight + layoutGapY
+		}
+	}
+
+	return boxes
+}
+
+// tableLayers returns layer of each table. Layer is the length of the longest foreign key path to a table which doesn't refer other tables.
+// Foreign keys which make a cycle are ignored
+func tableLayers(tables []*Table) map[string]int {
+	tablesByName := map[string]*Table{}
+	for _, table := range tables {
+		tablesByName[table.Name] = table
+	}
+
+	layers := map[string]int{}
+	visiting := map[string]bool{}
+
+	var visit func(table *Table) int
+	visit = func(table *Table) int {
+		if layer, ok := layers[table.Name]; ok {
+			return layer
+		}
+
+		visiting[table.Name] = true
+
+		layer := 0
+		for _, foreignKey := range table.ForeignKeys {
+			toTable, ok := tablesByName[strings.ToLower(foreignKey.ToTable)]
+			if !ok || visiting[toTable.Name] {
+				continue
+			}
+
+			layer = max(layer, visit(toTable)+1)
+		}
+
+		visiting[table.Name] = false
+		layers[table.Name] = layer
+
+		return layer
+	}
+
+	for _, table := range tables {
+		visit(table)
+	}
+
+	return layers
+}
@@ -0,0 +1,83 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_tableLayers(t *testing.T) {
+	tests := []struct {
+		name   string
+		tables []*Table
+		want   map[string]int
+	}{
+		{
+			name: "chain",
+			tables: []*Table{
+				{Name: "comments", ForeignKeys: []*ForeignKey{{FromColumn: "article_id", ToTable: "articles", ToColumn: "id"}}},
+				{Name: "articles", ForeignKeys: []*ForeignKey{{FromColumn: "user_id", ToTable: "users", ToColumn: "id"}}},
+				{Name: "users"},
+			},
+			want: map[string]int{"comments": 2, "articles": 1, "users": 0},
+		},
+		{
+			name: "self reference and unknown table",
+			tables: []*Table{
+				{Name: "users", ForeignKeys: []*ForeignKey{
+					{FromColumn: "parent_id", ToTable: "users", ToColumn: "id"},
+					{FromColumn: "company_id", ToTable: "companies", ToColumn: "id"},
+				}},
+			},
+			want: map[string]int{"users": 0},
+		},
+		{
+			name: "circular reference",
+			tables: []*Table{
+				{Name: "a", ForeignKeys: []*ForeignKey{{FromColumn: "b_id", ToTable: "b", ToColumn: "id"}}},
+				{Name: "b", ForeignKeys: []*ForeignKey{{FromColumn: "a_id", ToTable: "a", ToColumn: "id"}}},
+			},
+			want: map[string]int{"a": 1, "b": 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tableLayers(tt.tables))
+		})
+	}
+}
+
+func Test_layoutTables(t *testing.T) {
+	tables := []*Table{
+		{Name: "articles", ForeignKeys: []*ForeignKey{{FromColumn: "user_id", ToTable: "users", ToColumn: "id"}}},
+		{Name: "users"},
+		{Name: "tags"},
+	}
+
+	boxes := layoutTables(tables, func(table *Table) (float64, float64) {
+		return 100, float64(len(table.Name) * 10)
+	})
+
+	want := []*tableBox{
+		{table: tables[0], x: 40, y: 40 + 50 + 80, width: 100, height: 80},
+		{table: tables[1], x: 40, y: 40, width: 100, height: 50},
+		{table: tables[2], x: 40 + 100 + 60, y: 40, width: 100, height: 40},
+	}
+	assert.Equal(t, want, boxes)
+}
+
+func Test_layoutTables_Wrap(t *testing.T) {
+	var tables []*Table
+	for _, name := range []string{"a", "b", "c"} {
+		tables = append(tables, &Table{Name: name})
+	}
+
+	boxes := layoutTables(tables, func(*Table) (float64, float64) {
+		return 700, 100
+	})
+
+	assert.InDelta(t, 40, boxes[0].x, 0)
+	assert.InDelta(t, 40+700+60, boxes[1].x, 0)
+	assert.InDelta(t, 40, boxes[2].x, 0)
+	assert.InDelta(t, 40+100+80, boxes[2].y, 0)
+}
@@ -0,0 +1,182 @@
+package db
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var markdownAnchorRe = regexp.MustCompile(`[^a-z0-9_\- ]`)
+
+// ToMarkdown returns Markdown formatted data dictionary.
+// When embedMermaid is true, Mermaid ERD of immediate neighbourhood is embedded into each table section
+func (s *Schema) ToMarkdown(embedMermaid bool) string {
+	lines := []string{"# Tables"}
+
+	var toc []string
+	for _, table := range s.Tables {
+		toc = append(toc, fmt.Sprintf("* [%s](#%s)", table.Name, markdownAnchor(table.Name)))
+	}
+	if len(toc) > 0 {
+		lines = append(lines, strings.Join(toc, "\n"))
+	}
+
+	link := func(tableName string) string {
+		return "#" + markdownAnchor(tableName)
+	}
+
+	for _, table := range s.Tables {
+		lines = append(lines, s.markdownTable(table, "##", link, embedMermaid))
+	}
+
+	return strings.Join(lines, "\n\n") + "\n"
+}
+
+// ToMarkdownFiles returns Markdown formatted data dictionary which is split into one file per table.
+// Key of returned map is filename (e.g. users.md) and README.md is index of all tables
+func (s *Schema) ToMarkdownFiles(embedMermaid bool) map[string]string {
+	files := map[string]string{}
+
+	var toc []string
+	for _, table := range s.Tables {
+		toc = append(toc, fmt.Sprintf("* [%s](%s)", table.Name, MarkdownFilename(table.Name)))
+	}
+
+	index := []string{"# Tables"}
+	if len(toc) > 0 {
+		index = append(index, strings.Join(toc, "\n"))
+	}
+	files["README.md"] = strings.Join(index, "\n\n") + "\n"
+
+	for _, table := range s.Tables {
+		files[MarkdownFilename(table.Name)] = s.markdownTable(table, "#", MarkdownFilename, embedMermaid) + "\n"
+	}
+
+	return files
+}
+
+// MarkdownFilename returns filename of table for ToMarkdownFiles
+func MarkdownFilename(tableName string) string {
+	return strings.ReplaceAll(tableName, "/", "_") + ".md"
+}
+
+// markdownTable returns Markdown section of table. link returns link destination of table name
+func (s *Schema) markdownTable(table *Table, heading string, link func(string) string, embedMermaid bool) string {
+	subHeading := heading + "#"
+
+	sections := []string{
+		fmt.Sprintf("%s %s", heading, table.Name),
+	}
+
+	if table.Stats != nil {
+		sections = append(sections, table.Stats.String())
+	}
+
+	columns := [][]string{{"Name", "Type", "Nullable", "PK", "Default", "Comment"}}
+	for _, column := range table.Columns {
+		nullable := "YES"
+		if column.NotNull {
+			nullable = "NO"
+		}
+
+		columns = append(columns, []string{column.Name, column.Type, nullable, table.columnKey(column), column.Default, column.Comment})
+	}
+	sections = append(sections, subHeading+" Columns", markdownTableRows(columns))
+
+	if len(table.Indexes) > 0 {
+		indexes := [][]string{{"Name", "Definition", "Unique"}}
+		for _, index := range table.Indexes {
+			unique := ""
+			if index.Unique {
+				unique = "YES"
+			}
+			indexes = append(indexes, []string{index.Name, index.Definition(), unique})
+		}
+		sections = append(sections, subHeading+" Indexes", markdownTableRows(indexes))
+	}
+
+	tableNames := map[string]bool{}
+	for _, t := range s.Tables {
+		tableNames[t.Name] = true
+	}
+	resolver := NewTableResolver(s.Tables)
+
+	tableLink := func(tableName string) string {
+		if tableNames[tableName] {
+			return fmt.Sprintf("[%s](%s)", tableName, link(tableName))
+		}
+		return tableName
+	}
+
+	if len(table.ForeignKeys) > 0 {
+		var references []string
+		for _, foreignKey := range table.ForeignKeys {
+			toTable := strings.ToLower(foreignKey.ToTable)
+			if target := resolver.Find(foreignKey.ToTable); target != nil {
+				toTable = target.Name
+			}
+			reference := fmt.Sprintf("* `%s` → %s (`%s`)", foreignKey.FromColumn, tableLink(toTable), foreignKey.ToColumn)
+			if foreignKey.Inferred {
+				reference += " (inferred)"
+			}
+			references = append(references, reference)
+		}
+		sections = append(sections, subHeading+" References", strings.Join(references, "\n"))
+	}
+
+	var referencedBy []string
+	for _, other := range s.Tables {
+		for _, foreignKey := range other.ForeignKeys {
+			if resolver.Find(foreignKey.ToTable) == table {
+				referencedBy = append(referencedBy, fmt.Sprintf("* %s (`%s`) → `%s`", tableLink(other.Name), foreignKey.FromColumn, foreignKey.ToColumn))
+			}
+		}
+	}
+	if len(referencedBy) > 0 {
+		sections = append(sections, subHeading+" Referenced by", strings.Join(referencedBy, "\n"))
+	}
+
+	if embedMermaid {
+		subset := s.Subset(table.Name, 1)
+		sections = append(sections, subHeading+" ERD", "```mermaid\n"+subset.ToMermaid(DiagramOption{ShowComment: true})+"\n```")
+	}
+
+	return strings.Join(sections, "\n\n")
+}
+
+func markdownTableRows(rows [][]string) string {
+	var lines []string
+
+	for i, row := range rows {
+		var cells []string
+		for _, cell := range row {
+			cells = append(cells, markdownCell(cell))
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+
+		if i == 0 {
+			separators := make([]string, len(row))
+			for j := range separators {
+				separators[j] = "---"
+			}
+			lines = append(lines, "| "+strings.Join(separators, " | ")+" |")
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// markdownCell escapes characters which break table cell
+func markdownCell(str string) string {
+	str = strings.ReplaceAll(str, "|", `\|`)
+	str = strings.ReplaceAll(str, "\r\n", "<br>")
+	str = strings.ReplaceAll(str, "\n", "<br>")
+	return str
+}
+
+// markdownAnchor returns anchor of heading in the same way as GitHub
+func markdownAnchor(heading string) string {
+	anchor := strings.ToLower(heading)
+	anchor = markdownAnchorRe.ReplaceAllString(anchor, "")
+	return strings.ReplaceAll(anchor, " ", "-")
+}
@@ -0,0 +1,229 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func markdownTestSchema() *Schema {
+	return NewSchema([]*Table{
+		{
+			Name: "articles",
+			Columns: []*Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+				{Name: "user_id", Type: "integer", NotNull: true},
+				{Name: "status", Type: "text", Default: "'draft'", Comment: "draft | published"},
+			},
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+			},
+			Indexes: []*Index{
+				{Name: "index_user_id_on_articles", Columns: []string{"user_id"}},
+			},
+		},
+		{
+			Name: "users",
+			Columns: []*Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+				{Name: "name", Type: "text", Comment: "Full name\nof user"},
+			},
+		},
+	})
+}
+
+func TestSchema_ToMarkdown(t *testing.T) {
+	tests := []struct {
+		name         string
+		embedMermaid bool
+		want         string
+	}{
+		{
+			name:         "without mermaid",
+			embedMermaid: false,
+			want: "# Tables\n" +
+				"\n" +
+				"* [articles](#articles)\n" +
+				"* [users](#users)\n" +
+				"\n" +
+				"## articles\n" +
+				"\n" +
+				"### Columns\n" +
+				"\n" +
+				"| Name | Type | Nullable | PK | Default | Comment |\n" +
+				"| --- | --- | --- | --- | --- | --- |\n" +
+				"| id | integer | NO | PK |  |  |\n" +
+				"| user_id | integer | NO | FK |  |  |\n" +
+				"| status | text | YES |  | 'draft' | draft \\| published |\n" +
+				"\n" +
+				"### Indexes\n" +
+				"\n" +
+				"| Name | Definition | Unique |\n" +
+				"| --- | --- | --- |\n" +
+				"| index_user_id_on_articles | (user_id) |  |\n" +
+				"\n" +
+				"### References\n" +
+				"\n" +
+				"* `user_id` → [users](#users) (`id`)\n" +
+				"\n" +
+				"## users\n" +
+				"\n" +
+				"### Columns\n" +
+				"\n" +
+				"| Name | Type | Nullable | PK | Default | Comment |\n" +
+				"| --- | --- | --- | --- | --- | --- |\n" +
+				"| id | integer | NO | PK |  |  |\n" +
+				"| name | text | YES |  |  | Full name<br>of user |\n" +
+				"\n" +
+				"### Referenced by\n" +
+				"\n" +
+				"* [articles](#articles) (`user_id`) → `id`\n",
+		},
+		{
+			name:         "with mermaid",
+			embedMermaid: true,
+			want: "# Tables\n" +
+				"\n" +
+				"* [articles](#articles)\n" +
+				"* [users](#users)\n" +
+				"\n" +
+				"## articles\n" +
+				"\n" +
+				"### Columns\n" +
+				"\n" +
+				"| Name | Type | Nullable | PK | Default | Comment |\n" +
+				"| --- | --- | --- | --- | --- | --- |\n" +
+				"| id | integer | NO | PK |  |  |\n" +
+				"| user_id | integer | NO | FK |  |  |\n" +
+				"| status | text | YES |  | 'draft' | draft \\| published |\n" +
+				"\n" +
+				"### Indexes\n" +
+				"\n" +
+				"| Name | Definition | Unique |\n" +
+				"| --- | --- | --- |\n" +
+				"| index_user_id_on_articles | (user_id) |  |\n" +
+				"\n" +
+				"### References\n" +
+				"\n" +
+				"* `user_id` → [users](#users) (`id`)\n" +
+				"\n" +
+				"### ERD\n" +
+				"\n" +
+				"```mermaid\n" +
+				"erDiagram\n" +
+				"\n" +
+				"articles {\n" +
+				"  integer id PK \"not null\"\n" +
+				"  integer user_id FK \"not null\"\n" +
+				"  text status\n" +
+				"}\n" +
+				"\n" +
+				"users {\n" +
+				"  integer id PK \"not null\"\n" +
+				"  text name\n" +
+				"}\n" +
+				"\n" +
+				"users ||--o{ articles : owns\n" +
+				"```\n" +
+				"\n" +
+				"## users\n" +
+				"\n" +
+				"### Columns\n" +
+				"\n" +
+				"| Name | Type | Nullable | PK | Default | Comment |\n" +
+				"| --- | --- | --- | --- | --- | --- |\n" +
+				"| id | integer | NO | PK |  |  |\n" +
+				"| name | text | YES |  |  | Full name<br>of user |\n" +
+				"\n" +
+				"### Referenced by\n" +
+				"\n" +
+				"* [articles](#articles) (`user_id`) → `id`\n" +
+				"\n" +
+				"### ERD\n" +
+				"\n" +
+				"```mermaid\n" +
+				"erDiagram\n" +
+				"\n" +
+				"articles {\n" +
+				"  integer id PK \"not null\"\n" +
+				"  integer user_id FK \"not null\"\n" +
+				"  text status\n" +
+				"}\n" +
+				"\n" +
+				"users {\n" +
+				"  integer id PK \"not null\"\n" +
+				"  text name\n" +
+				"}\n" +
+				"\n" +
+				"users ||--o{ articles : owns\n" +
+				"```\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := markdownTestSchema()
+			assert.Equal(t, tt.want, s.ToMarkdown(tt.embedMermaid))
+		})
+	}
+}
+
+func TestSchema_ToMarkdownFiles(t *testing.T) {
+	s := markdownTestSchema()
+
+	got := s.ToMarkdownFiles(false)
+
+	want := map[string]string{
+		"README.md": "# Tables\n" +
+			"\n" +
+			"* [articles](articles.md)\n" +
+			"* [users](users.md)\n",
+		"articles.md": "# articles\n" +
+			"\n" +
+			"## Columns\n" +
+			"\n" +
+			"| Name | Type | Nullable | PK | Default | Comment |\n" +
+			"| --- | --- | --- | --- | --- | --- |\n" +
+			"| id | integer | NO | PK |  |  |\n" +
+			"| user_id | integer | NO | FK |  |  |\n" +
+			"| status | text | YES |  | 'draft' | draft \\| published |\n" +
+			"\n" +
+			"## Indexes\n" +
+			"\n" +
+			"| Name | Definition | Unique |\n" +
+			"| --- | --- | --- |\n" +
+			"| index_user_id_on_articles | (user_id) |  |\n" +
+			"\n" +
+			"## References\n" +
+			"\n" +
+			"* `user_id` → [users](users.md) (`id`)\n",
+		"users.md": "# users\n" +
+			"\n" +
+			"## Columns\n" +
+			"\n" +
+			"| Name | Type | Nullable | PK | Default | Comment |\n" +
+			"| --- | --- | --- | --- | --- | --- |\n" +
+			"| id | integer | NO | PK |  |  |\n" +
+			"| name | text | YES |  |  | Full name<br>of user |\n" +
+			"\n" +
+			"## Referenced by\n" +
+			"\n" +
+			"* [articles](articles.md) (`user_id`) → `id`\n",
+	}
+	assert.Equal(t, want, got)
+}
+
+func Test_markdownAnchor(t *testing.T) {
+	tests := []struct {
+		heading string
+		want    string
+	}{
+		{heading: "users", want: "users"},
+		{heading: "Order Items", want: "order-items"},
+		{heading: "public.users", want: "publicusers"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.heading, func(t *testing.T) {
+			assert.Equal(t, tt.want, markdownAnchor(tt.heading))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PlantUmlDecoration represents theme, skinparam, title, header, footer and legend of PlantUML
+type PlantUmlDecoration struct {
+	// Theme represents name of PlantUML theme (e.g. cerulean)
+	Theme string
+
+	// SkinParams represents skinparam as "name value" (e.g. "linetype ortho")
+	SkinParams []string
+
+	Title  string
+	Header string
+	Footer string
+
+	// Legend represents text of legend. This can be multiline
+	Legend string
+}
+
+// Decorate returns PlantUML which body is surrounded with decoration
+func (d *PlantUmlDecoration) Decorate(body string) string {
+	var lines []string
+
+	if d.Theme != "" {
+		lines = append(lines, "!theme "+d.Theme)
+	}
+
+	if len(d.SkinParams) > 0 {
+		var skinParams []string
+		for _, skinParam := range d.SkinParams {
+			skinParams = append(skinParams, "skinparam "+skinParam)
+		}
+		lines = append(lines, strings.Join(skinParams, "\n"))
+	}
+
+	if d.Title != "" {
+		lines = append(lines, "title "+d.Title)
+	}
+
+	if d.Header != "" {
+		lines = append(lines, "header "+d.Header)
+	}
+
+	if d.Footer != "" {
+		lines = append(lines, "footer "+d.Footer)
+	}
+
+	if len(lines) == 0 && d.Legend == "" {
+		return body
+	}
+
+	lines = append(lines, body)
+
+	if d.Legend != "" {
+		lines = append(lines, fmt.Sprintf("legend\n%s\nendlegend", d.Legend))
+	}
+
+	return strings.Join(lines, "\n\n")
+}
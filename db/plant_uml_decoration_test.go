@@ -0,0 +1,62 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlantUmlDecoration_Decorate(t *testing.T) {
+	body := "entity users {\n}"
+
+	tests := []struct {
+		name       string
+		decoration PlantUmlDecoration
+		want       string
+	}{
+		{
+			name:       "empty",
+			decoration: PlantUmlDecoration{},
+			want:       body,
+		},
+		{
+			name: "full",
+			decoration: PlantUmlDecoration{
+				Theme:      "cerulean",
+				SkinParams: []string{"linetype ortho", "shadowing false"},
+				Title:      "Example ERD",
+				Header:     "ACME Inc.",
+				Footer:     "generated by plant_erd",
+				Legend:     "red: deprecated\nyellow: no primary key",
+			},
+			want: `!theme cerulean
+
+skinparam linetype ortho
+skinparam shadowing false
+
+title Example ERD
+
+header ACME Inc.
+
+footer generated by plant_erd
+
+entity users {
+}
+
+legend
+red: deprecated
+yellow: no primary key
+endlegend`,
+		},
+		{
+			name:       "only legend",
+			decoration: PlantUmlDecoration{Legend: "note"},
+			want:       "entity users {\n}\n\nlegend\nnote\nendlegend",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.decoration.Decorate(body))
+		})
+	}
+}
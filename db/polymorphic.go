@@ -0,0 +1,133 @@
+package db
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// PolymorphicTag represents tag of placeholder table which is referred by polymorphic association whose referenced tables are unknown
+const PolymorphicTag = "polymorphic"
+
+// PolymorphicAssociation represents Rails-style polymorphic association which consists of <name>_type and <name>_id columns (e.g. commentable_type and commentable_id)
+type PolymorphicAssociation struct {
+	Name       string
+	TypeColumn string
+	IDColumn   string
+
+	// Type represents value of TypeColumn which refers the table (e.g. Article). This is empty when referenced tables are unknown
+	Type string
+}
+
+var (
+	underscoreAcronymRe = regexp.MustCompile(`([A-Z\d]+)([A-Z][a-z])`)
+	underscoreWordRe    = regexp.MustCompile(`([a-z\d])([A-Z])`)
+)
+
+// PolymorphicAssociations returns pairs of <name>_type and <name>_id columns in table. Pairs which <name>_id has foreign key are ignored
+func (t *Table) PolymorphicAssociations() []*PolymorphicAssociation {
+	foreignKeyColumns := map[string]bool{}
+	for _, foreignKey := range t.ForeignKeys {
+		foreignKeyColumns[strings.ToLower(foreignKey.FromColumn)] = true
+	}
+
+	var associations []*PolymorphicAssociation
+	for _, column := range t.Columns {
+		if len(column.Name) <= len("_type") || !strings.HasSuffix(strings.ToLower(column.Name), "_type") {
+			continue
+		}
+
+		name := column.Name[:len(column.Name)-len("_type")]
+		idColumn := t.FindColumnIgnoreCase(name + "_id")
+		if idColumn == nil || foreignKeyColumns[strings.ToLower(idColumn.Name)] {
+			continue
+		}
+
+		associations = append(associations, &PolymorphicAssociation{Name: name, TypeColumn: column.Name, IDColumn: idColumn.Name})
+	}
+
+	return associations
+}
+
+// DetectPolymorphicAssociations returns schema which has foreign keys of polymorphic associations in addition to existing foreign keys.
+// Referenced tables are resolved from Column.SampleValues of type column (e.g. Article -> articles).
+// When values aren't sampled or none of them are resolved, foreign keys refer placeholder table which is named after association and tagged with PolymorphicTag
+func (s *Schema) DetectPolymorphicAssociations() *Schema {
+	resolver := NewTableResolver(s.Tables)
+
+	var tables []*Table
+	var placeholderNames []string
+	for _, table := range s.Tables {
+		associations := table.PolymorphicAssociations()
+		if len(associations) == 0 {
+			tables = append(tables, table)
+			continue
+		}
+
+		copied := *table
+		copied.ForeignKeys = append([]*ForeignKey(nil), table.ForeignKeys...)
+
+		for _, association := range associations {
+			resolved := false
+			for _, typeName := range table.findColumn(association.TypeColumn).SampleValues {
+				toTable := findPolymorphicTable(table, typeName, resolver)
+				if toTable == nil {
+					continue
+				}
+				resolved = true
+
+				toColumn := "id"
+				if columns := toTable.GetPrimaryKeyColumns(); len(columns) > 0 {
+					toColumn = columns[0].Name
+				}
+
+				typed := *association
+				typed.Type = typeName
+				copied.ForeignKeys = append(copied.ForeignKeys, &ForeignKey{FromColumn: association.IDColumn, ToTable: toTable.Name, ToColumn: toColumn, Inferred: true, Polymorphic: &typed})
+			}
+
+			if resolved {
+				continue
+			}
+
+			placeholderName := strings.ToLower(association.Name)
+			if resolver.Find(placeholderName) == nil && !slices.Contains(placeholderNames, placeholderName) {
+				placeholderNames = append(placeholderNames, placeholderName)
+			}
+
+			copied.ForeignKeys = append(copied.ForeignKeys, &ForeignKey{FromColumn: association.IDColumn, ToTable: placeholderName, ToColumn: "id", Inferred: true, Polymorphic: association})
+		}
+
+		tables = append(tables, &copied)
+	}
+
+	for _, placeholderName := range placeholderNames {
+		tables = append(tables, &Table{Name: placeholderName, Tags: []string{PolymorphicTag}})
+	}
+
+	return NewSchema(tables)
+}
+
+// findPolymorphicTable returns table which is referred by type name (e.g. Article -> articles, Admin::User -> admin_users or users). This returns nil when table isn't found
+func findPolymorphicTable(table *Table, typeName string, resolver *TableResolver) *Table {
+	name := underscore(strings.ReplaceAll(typeName, "::", "_"))
+	names := []string{Pluralize(name), name}
+	if i := strings.LastIndex(typeName, "::"); i >= 0 {
+		name = underscore(typeName[i+len("::"):])
+		names = append(names, Pluralize(name), name)
+	}
+
+	// Prefer table in the same schema (e.g. public.comments.commentable_type -> public.articles)
+	if tables := resolver.findNear(table, names); len(tables) > 0 {
+		return tables[0]
+	}
+
+	return nil
+}
+
+// underscore returns snake case of word (e.g. BlogPost -> blog_post)
+func underscore(word string) string {
+	word = underscoreAcronymRe.ReplaceAllString(word, "${1}_${2}")
+	word = underscoreWordRe.ReplaceAllString(word, "${1}_${2}")
+	return strings.ToLower(word)
+}
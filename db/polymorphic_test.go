@@ -0,0 +1,194 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func polymorphicTestSchema(sampleValues []string) *Schema {
+	return NewSchema([]*Table{
+		{
+			Name: "admin_users",
+			Columns: []*Column{
+				{Name: "id", Type: "integer", PrimaryKey: true},
+			},
+		},
+		{
+			Name: "articles",
+			Columns: []*Column{
+				{Name: "id", Type: "integer", PrimaryKey: true},
+			},
+		},
+		{
+			Name: "comments",
+			Columns: []*Column{
+				{Name: "id", Type: "integer", PrimaryKey: true},
+				{Name: "commentable_type", Type: "varchar(255)", SampleValues: sampleValues},
+				{Name: "commentable_id", Type: "integer"},
+				{Name: "user_id", Type: "integer"},
+				{Name: "user_type", Type: "varchar(255)"},
+			},
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "user_id", ToTable: "admin_users", ToColumn: "id"},
+			},
+		},
+		{
+			Name: "photos",
+			Columns: []*Column{
+				{Name: "photo_id", Type: "integer", PrimaryKey: true},
+			},
+		},
+	})
+}
+
+func TestTable_PolymorphicAssociations(t *testing.T) {
+	table := &Table{
+		Name: "comments",
+		Columns: []*Column{
+			{Name: "commentable_type", Type: "varchar(255)"},
+			{Name: "commentable_id", Type: "integer"},
+			{Name: "Owner_Type", Type: "varchar(255)"},
+			{Name: "owner_id", Type: "integer"},
+			{Name: "user_type", Type: "varchar(255)"},
+			{Name: "user_id", Type: "integer"},
+			{Name: "content_type", Type: "varchar(255)"},
+			{Name: "_type", Type: "varchar(255)"},
+		},
+		ForeignKeys: []*ForeignKey{
+			{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+		},
+	}
+
+	assert.Equal(t, []*PolymorphicAssociation{
+		{Name: "commentable", TypeColumn: "commentable_type", IDColumn: "commentable_id"},
+		{Name: "Owner", TypeColumn: "Owner_Type", IDColumn: "owner_id"},
+	}, table.PolymorphicAssociations())
+}
+
+func TestSchema_DetectPolymorphicAssociations(t *testing.T) {
+	t.Run("with sample values", func(t *testing.T) {
+		s := polymorphicTestSchema([]string{"Admin::User", "Article", "Photo", "Unknown"})
+		got := s.DetectPolymorphicAssociations()
+
+		association := PolymorphicAssociation{Name: "commentable", TypeColumn: "commentable_type", IDColumn: "commentable_id"}
+		adminUser, article, photo := association, association, association
+		adminUser.Type = "Admin::User"
+		article.Type = "Article"
+		photo.Type = "Photo"
+
+		assert.Len(t, got.Tables, 4)
+		assert.Equal(t, []*ForeignKey{
+			{FromColumn: "user_id", ToTable: "admin_users", ToColumn: "id"},
+			{FromColumn: "commentable_id", ToTable: "admin_users", ToColumn: "id", Inferred: true, Polymorphic: &adminUser},
+			{FromColumn: "commentable_id", ToTable: "articles", ToColumn: "id", Inferred: true, Polymorphic: &article},
+			{FromColumn: "commentable_id", ToTable: "photos", ToColumn: "photo_id", Inferred: true, Polymorphic: &photo},
+		}, got.findTable("comments").ForeignKeys)
+
+		// original schema isn't modified
+		assert.Len(t, s.findTable("comments").ForeignKeys, 1)
+	})
+
+	t.Run("without sample values", func(t *testing.T) {
+		got := polymorphicTestSchema(nil).DetectPolymorphicAssociations()
+
+		assert.Len(t, got.Tables, 5)
+		assert.Equal(t, &Table{Name: "commentable", Tags: []string{PolymorphicTag}}, got.Tables[4])
+		assert.Equal(t, &ForeignKey{
+			FromColumn:  "commentable_id",
+			ToTable:     "commentable",
+			ToColumn:    "id",
+			Inferred:    true,
+			Polymorphic: &PolymorphicAssociation{Name: "commentable", TypeColumn: "commentable_type", IDColumn: "commentable_id"},
+		}, got.findTable("comments").ForeignKeys[1])
+	})
+
+	t.Run("without resolved sample values", func(t *testing.T) {
+		got := polymorphicTestSchema([]string{"Unknown", "Other::Thing"}).DetectPolymorphicAssociations()
+
+		assert.Len(t, got.Tables, 5)
+		assert.Equal(t, &Table{Name: "commentable", Tags: []string{PolymorphicTag}}, got.Tables[4])
+		assert.Equal(t, []*ForeignKey{
+			{FromColumn: "user_id", ToTable: "admin_users", ToColumn: "id"},
+			{
+				FromColumn:  "commentable_id",
+				ToTable:     "commentable",
+				ToColumn:    "id",
+				Inferred:    true,
+				Polymorphic: &PolymorphicAssociation{Name: "commentable", TypeColumn: "commentable_type", IDColumn: "commentable_id"},
+			},
+		}, got.findTable("comments").ForeignKeys)
+	})
+}
+
+func TestSchema_DetectPolymorphicAssociations_withUncountableSuffix(t *testing.T) {
+	s := NewSchema([]*Table{
+		{
+			Name: "discounts",
+			Columns: []*Column{
+				{Name: "discountable_type", Type: "varchar(255)", SampleValues: []string{"Price"}},
+				{Name: "discountable_id", Type: "integer"},
+			},
+		},
+		{
+			Name:    "prices",
+			Columns: []*Column{{Name: "id", Type: "integer", PrimaryKey: true}},
+		},
+	})
+
+	got := s.DetectPolymorphicAssociations()
+
+	assert.Len(t, got.Tables, 2)
+	assert.Equal(t, "prices", got.findTable("discounts").ForeignKeys[0].ToTable)
+}
+
+func TestSchema_ToErd_withPolymorphicAssociation(t *testing.T) {
+	s := NewSchema([]*Table{
+		{
+			Name:    "articles",
+			Columns: []*Column{{Name: "id", Type: "integer", PrimaryKey: true}},
+		},
+		{
+			Name: "comments",
+			Columns: []*Column{
+				{Name: "commentable_type", Type: "varchar(255)", SampleValues: []string{"Article"}},
+				{Name: "commentable_id", Type: "integer"},
+			},
+		},
+	}).DetectPolymorphicAssociations()
+
+	assert.Contains(t, s.ToErd(DiagramOption{}), "comments }.. articles : commentable")
+	assert.Contains(t, s.ToErd(DiagramOption{Relation: RelationOption{Label: "join"}}), "comments }.. articles : commentable_id = articles.id AND commentable_type = 'Article'")
+	assert.Contains(t, s.ToMermaid(DiagramOption{}), `articles ||..o{ comments : "commentable"`)
+}
+
+func TestSchema_ToErd_withPolymorphicPlaceholder(t *testing.T) {
+	s := NewSchema([]*Table{
+		{
+			Name: "comments",
+			Columns: []*Column{
+				{Name: "commentable_type", Type: "varchar(255)"},
+				{Name: "commentable_id", Type: "integer"},
+			},
+		},
+	}).DetectPolymorphicAssociations()
+
+	erd := s.ToErd(DiagramOption{})
+	assert.Contains(t, erd, "entity commentable <<polymorphic>> {\n}")
+	assert.Contains(t, erd, "comments }.. commentable : commentable")
+	assert.Contains(t, s.ToMermaid(DiagramOption{}), `commentable ||..o{ comments : "commentable"`)
+}
+
+func Test_underscore(t *testing.T) {
+	tests := map[string]string{
+		"Article":   "article",
+		"BlogPost":  "blog_post",
+		"HTMLPage":  "html_page",
+		"Photo2Tag": "photo2_tag",
+	}
+	for word, want := range tests {
+		t.Run(word, func(t *testing.T) {
+			assert.Equal(t, want, underscore(word))
+		})
+	}
+}
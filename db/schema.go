@@ -16,22 +16,72 @@ func NewSchema(tables []*Table) *Schema {
 	return &Schema{Tables: tables}
 }
 
-// ToErd returns ERD formatted schema
-func (s *Schema) ToErd(showIndex bool) string {
+// DiagramOption represents option for ToErd, ToMermaid, ToClassDiagram and ToMermaidClassDiagram
+type DiagramOption struct {
+	ShowIndex   bool
+	ShowComment bool
+	ShowTrigger bool
+
+	// Columns represents which columns are printed (all, keys, none)
+	Columns string
+
+	// GroupStyle represents PlantUML block which wraps tables of the same group (package, namespace, rectangle)
+	GroupStyle string
+
+	// Relation represents how relations between tables are drawn
+	Relation RelationOption
+}
+
+// ToErd returns ERD formatted schema. Tables which have Group are wrapped in option.GroupStyle block (package, namespace, rectangle)
+func (s *Schema) ToErd(option DiagramOption) string {
 	var lines []string
-	tableNames := mapset.NewSet[string]()
+	resolver := NewTableResolver(s.Tables)
+	entityNames := s.plantUmlEntityNames(option.GroupStyle)
 
-	for _, table := range s.Tables {
-		lines = append(lines, table.ToErd(showIndex))
-		tableNames.Add(table.Name)
+	for _, group := range s.tableGroups() {
+		var entities []string
+		for _, table := range group.tables {
+			entities = append(entities, table.ToErd(option.ShowIndex, option.Columns))
+		}
+
+		if group.name == "" {
+			lines = append(lines, entities...)
+		} else {
+			lines = append(lines, wrapPlantUmlGroup(option.GroupStyle, group.name, entities))
+		}
 	}
 
 	for _, table := range s.Tables {
-		for _, foreignKey := range table.ForeignKeys {
-			toTable := strings.ToLower(foreignKey.ToTable)
-			if tableNames.Contains(toTable) {
-				lines = append(lines, fmt.Sprintf("%s }-- %s", table.Name, toTable))
+		for _, foreignKeys := range groupForeignKeys(table.ForeignKeys) {
+			target := resolver.Find(foreignKeys[0].ToTable)
+			if target == nil {
+				continue
+			}
+			toTable := target.Name
+
+			from, to := entityNames[table.Name], entityNames[toTable]
+			if option.Relation.LinkColumn {
+				from += "::" + foreignKeys[0].FromColumn
+				to += "::" + foreignKeys[0].ToColumn
+			}
+
+			// Inferred relation is drawn with dashed line
+			arrow := "}--"
+			if foreignKeys[0].Inferred {
+				arrow = "}.."
 			}
+
+			line := fmt.Sprintf("%s %s %s", from, arrow, to)
+			if label := option.Relation.relationLabel(foreignKeys); label != "" {
+				line += " : " + label
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	if option.ShowTrigger {
+		for _, relation := range s.triggerRelations() {
+			lines = append(lines, fmt.Sprintf("%s ..> %s : %s", entityNames[relation.fromTable], entityNames[relation.toTable], relation.trigger.Name))
 		}
 	}
 
@@ -39,29 +89,115 @@ func (s *Schema) ToErd(showIndex bool) string {
 }
 
 // ToMermaid returns Mermaid formatted table
-func (s *Schema) ToMermaid(showComment bool) string {
+func (s *Schema) ToMermaid(option DiagramOption) string {
 	var lines []string
-	tableNames := mapset.NewSet[string]()
+	resolver := NewTableResolver(s.Tables)
 
 	lines = append(lines, "erDiagram")
 
+	// erDiagram doesn't support grouping, so tables of the same group are put together with comment
+	for _, group := range s.tableGroups() {
+		if group.name != "" {
+			lines = append(lines, "%% "+group.name)
+		}
+
+		for _, table := range group.tables {
+			lines = append(lines, table.ToMermaid(option.ShowComment, option.Columns))
+		}
+	}
+
 	for _, table := range s.Tables {
-		lines = append(lines, table.ToMermaid(showComment))
-		tableNames.Add(table.Name)
+		for _, foreignKeys := range groupForeignKeys(table.ForeignKeys) {
+			target := resolver.Find(foreignKeys[0].ToTable)
+			if target == nil {
+				continue
+			}
+			toTable := target.Name
+
+			label := "owns"
+			if str := option.Relation.relationLabel(foreignKeys); str != "" {
+				label = fmt.Sprintf("\"%s\"", str)
+			}
+			// Inferred relation is drawn with dashed line (non-identifying relationship)
+			arrow := "||--o{"
+			if foreignKeys[0].Inferred {
+				arrow = "||..o{"
+			}
+			lines = append(lines, fmt.Sprintf("%s %s %s : %s", toTable, arrow, table.Name, label))
+		}
+	}
+
+	if option.ShowTrigger {
+		for _, relation := range s.triggerRelations() {
+			lines = append(lines, fmt.Sprintf("%s }o..o{ %s : \"%s\"", relation.fromTable, relation.toTable, relation.trigger.Name))
+		}
+	}
+
+	return strings.Join(lines, "\n\n")
+}
+
+// ToD2 returns D2 formatted schema
+func (s *Schema) ToD2(showTrigger bool) string {
+	var lines []string
+	resolver := NewTableResolver(s.Tables)
+
+	for _, table := range s.Tables {
+		lines = append(lines, table.ToD2())
 	}
 
 	for _, table := range s.Tables {
 		for _, foreignKey := range table.ForeignKeys {
-			toTable := strings.ToLower(foreignKey.ToTable)
-			if tableNames.Contains(toTable) {
-				lines = append(lines, fmt.Sprintf("%s ||--o{ %s : owns", toTable, table.Name))
+			target := resolver.Find(foreignKey.ToTable)
+			if target == nil {
+				continue
+			}
+			toTable := target.Name
+
+			line := fmt.Sprintf("%s.%s -> %s.%s", d2Key(table.Name), d2Key(foreignKey.FromColumn), d2Key(toTable), d2Key(foreignKey.ToColumn))
+			if foreignKey.Inferred {
+				line += " {style.stroke-dash: 3}"
 			}
+			lines = append(lines, line)
+		}
+	}
+
+	if showTrigger {
+		for _, relation := range s.triggerRelations() {
+			lines = append(lines, fmt.Sprintf("%s -> %s: %s {style.stroke-dash: 3}", d2Key(relation.fromTable), d2Key(relation.toTable), d2String(relation.trigger.Name)))
 		}
 	}
 
 	return strings.Join(lines, "\n\n")
 }
 
+type triggerRelation struct {
+	fromTable string
+	toTable   string
+	trigger   *Trigger
+}
+
+// triggerRelations returns relations between table and tables which are referenced in its trigger body
+func (s *Schema) triggerRelations() []*triggerRelation {
+	var tableNames []string
+	for _, table := range s.Tables {
+		tableNames = append(tableNames, table.Name)
+	}
+
+	var relations []*triggerRelation
+	for _, table := range s.Tables {
+		for _, trigger := range table.Triggers {
+			for _, toTable := range trigger.ReferencedTables(tableNames) {
+				if toTable == table.Name {
+					continue
+				}
+				relations = append(relations, &triggerRelation{fromTable: table.Name, toTable: toTable, trigger: trigger})
+			}
+		}
+	}
+
+	return relations
+}
+
 // Subset returns subset of a schema
 func (s *Schema) Subset(tableName string, distance int) *Schema {
 	explorer := NewSchemaExplorer(s)
@@ -79,6 +215,137 @@ func (s *Schema) Subset(tableName string, distance int) *Schema {
 	return NewSchema(tables)
 }
 
+// PathSubset returns subset of a schema which has only tables and foreign keys on paths (c.f. SchemaExplorer.ShortestPaths)
+func (s *Schema) PathSubset(paths [][]string) *Schema {
+	tableNames := mapset.NewSet[string]()
+	edges := mapset.NewSet[string]()
+	for _, path := range paths {
+		tableNames.Append(path...)
+		for i := 0; i < len(path)-1; i++ {
+			edges.Add(edgeKey(path[i], path[i+1]))
+		}
+	}
+
+	resolver := NewTableResolver(s.Tables)
+
+	var tables []*Table
+	for _, table := range s.Tables {
+		if !tableNames.Contains(table.Name) {
+			continue
+		}
+
+		var foreignKeys []*ForeignKey
+		for _, foreignKey := range table.ForeignKeys {
+			if edges.Contains(edgeKey(table.Name, resolver.ResolveName(foreignKey.ToTable))) {
+				foreignKeys = append(foreignKeys, foreignKey)
+			}
+		}
+
+		copied := *table
+		copied.ForeignKeys = foreignKeys
+		tables = append(tables, &copied)
+	}
+
+	return NewSchema(tables)
+}
+
+// CollapsePartitions returns schema which partitions are merged into their parent table
+func (s *Schema) CollapsePartitions() *Schema {
+	partitionParents := s.partitionRoots()
+	if len(partitionParents) == 0 {
+		return s
+	}
+
+	// Statistics of partitions are aggregated into root table because rows are stored in partitions
+	partitionStats := map[string]*TableStats{}
+	for _, table := range s.Tables {
+		root, ok := partitionParents[table.Name]
+		if !ok || table.Stats == nil {
+			continue
+		}
+
+		stats, ok := partitionStats[root]
+		if !ok {
+			stats = &TableStats{}
+			partitionStats[root] = stats
+		}
+		stats.Rows += table.Stats.Rows
+		stats.Size += table.Stats.Size
+	}
+
+	var tables []*Table
+	for _, table := range s.Tables {
+		if _, ok := partitionParents[table.Name]; ok {
+			continue
+		}
+
+		var foreignKeys []*ForeignKey
+		for _, foreignKey := range table.ForeignKeys {
+			if parent, ok := partitionParents[foreignKey.ToTable]; ok {
+				// Refer parent table instead of partition
+				copied := *foreignKey
+				copied.ToTable = parent
+				foreignKey = &copied
+			}
+			foreignKeys = append(foreignKeys, foreignKey)
+		}
+
+		copied := *table
+		copied.ForeignKeys = foreignKeys
+
+		if stats, ok := partitionStats[table.Name]; ok {
+			if table.Stats != nil {
+				stats.Rows += table.Stats.Rows
+				stats.Size += table.Stats.Size
+			}
+			copied.Stats = stats
+		}
+
+		tables = append(tables, &copied)
+	}
+
+	return NewSchema(tables)
+}
+
+// PartitionRoot returns name of the root table of partition tree which tableName is merged into with CollapsePartitions.
+// This returns tableName when tableName isn't a partition
+func (s *Schema) PartitionRoot(tableName string) string {
+	if root, ok := s.partitionRoots()[tableName]; ok {
+		return root
+	}
+	return tableName
+}
+
+// partitionRoots returns the root table of partition tree of each partition
+func (s *Schema) partitionRoots() map[string]string {
+	tableNames := mapset.NewSet[string]()
+	for _, table := range s.Tables {
+		tableNames.Add(table.Name)
+	}
+
+	partitionParents := map[string]string{}
+	for _, table := range s.Tables {
+		if table.PartitionOf != "" && tableNames.Contains(table.PartitionOf) {
+			partitionParents[table.Name] = table.PartitionOf
+		}
+	}
+
+	// Sub-partitions are merged into the root table of partition tree
+	for name := range partitionParents {
+		root := partitionParents[name]
+		for i := 0; i < len(partitionParents); i++ {
+			parent, ok := partitionParents[root]
+			if !ok {
+				break
+			}
+			root = parent
+		}
+		partitionParents[name] = root
+	}
+
+	return partitionParents
+}
+
 func (s *Schema) findTable(tableName string) *Table {
 	for _, table := range s.Tables {
 		if table.Name == tableName {
@@ -13,10 +13,11 @@ type SchemaExplorer struct {
 
 // NewSchemaExplorer returns a new SchemaExplorer instance
 func NewSchemaExplorer(schema *Schema) *SchemaExplorer {
+	resolver := NewTableResolver(schema.Tables)
 	graph := NewUndirectedGraph()
 	for _, table := range schema.Tables {
 		for _, foreignKey := range table.ForeignKeys {
-			graph.PutSymmetric(table.Name, foreignKey.ToTable, true)
+			graph.PutSymmetric(table.Name, resolver.ResolveName(foreignKey.ToTable), true)
 		}
 	}
 
@@ -29,23 +30,215 @@ func (e *SchemaExplorer) Explore(tableName string, distance int) []string {
 		distance = 0
 	}
 
-	foundTableNames := mapset.NewSet[string]()
-
-	e.explore(tableName, distance, foundTableNames, 0)
-
-	tableNames := foundTableNames.ToSlice()
+	var tableNames []string
+	for name, d := range e.Distances(tableName) {
+		if d <= distance {
+			tableNames = append(tableNames, name)
+		}
+	}
 
 	sort.Strings(tableNames)
 	return tableNames
 }
 
-func (e *SchemaExplorer) explore(tableName string, distance int, foundTableNames mapset.Set[string], pos int) {
-	if pos > distance || foundTableNames.Contains(tableName) {
-		return
+// Distances returns distances from table to tables which are reachable from table with breadth-first search
+func (e *SchemaExplorer) Distances(tableName string) map[string]int {
+	distances := map[string]int{tableName: 0}
+	queue := []string{tableName}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, aroundTableName := range e.graph.GetRowColumns(current) {
+			if _, ok := distances[aroundTableName]; ok {
+				continue
+			}
+			distances[aroundTableName] = distances[current] + 1
+			queue = append(queue, aroundTableName)
+		}
 	}
-	foundTableNames.Add(tableName)
 
-	for _, aroundTableName := range e.graph.GetRowColumns(tableName) {
-		e.explore(aroundTableName, distance, foundTableNames, pos+1)
+	return distances
+}
+
+// ShortestPaths returns paths of table names from fromTable to toTable.
+// When limit is 0, this returns all paths which have the shortest length. Otherwise this returns up to limit paths in ascending order of length (k shortest paths)
+func (e *SchemaExplorer) ShortestPaths(fromTable string, toTable string, limit int) [][]string {
+	if limit <= 0 {
+		return e.allShortestPaths(fromTable, toTable)
+	}
+	return e.kShortestPaths(fromTable, toTable, limit)
+}
+
+// allShortestPaths returns all paths which have the shortest length with breadth-first search
+func (e *SchemaExplorer) allShortestPaths(fromTable string, toTable string) [][]string {
+	distances := map[string]int{fromTable: 0}
+	parents := map[string][]string{}
+	queue := []string{fromTable}
+
+	for len(queue) > 0 {
+		tableName := queue[0]
+		queue = queue[1:]
+
+		if tableName == toTable {
+			break
+		}
+
+		for _, next := range e.graph.GetRowColumns(tableName) {
+			distance, ok := distances[next]
+			if !ok {
+				distances[next] = distances[tableName] + 1
+				queue = append(queue, next)
+			} else if distance != distances[tableName]+1 {
+				continue
+			}
+			parents[next] = append(parents[next], tableName)
+		}
+	}
+
+	if _, ok := distances[toTable]; !ok {
+		return nil
+	}
+
+	var paths [][]string
+	var walk func(tableName string, path []string)
+	walk = func(tableName string, path []string) {
+		path = append([]string{tableName}, path...)
+		if tableName == fromTable {
+			paths = append(paths, path)
+			return
+		}
+		for _, parent := range parents[tableName] {
+			walk(parent, path)
+		}
+	}
+	walk(toTable, nil)
+
+	sortPaths(paths)
+	return paths
+}
+
+// kShortestPaths returns up to limit shortest simple paths with Yen's algorithm
+func (e *SchemaExplorer) kShortestPaths(fromTable string, toTable string, limit int) [][]string {
+	shortest := e.shortestPath(fromTable, toTable, mapset.NewSet[string](), mapset.NewSet[string]())
+	if shortest == nil {
+		return nil
+	}
+
+	paths := [][]string{shortest}
+	var candidates [][]string
+
+	for len(paths) < limit {
+		previous := paths[len(paths)-1]
+
+		for i := 0; i < len(previous)-1; i++ {
+			spurTable := previous[i]
+			rootPath := previous[:i+1]
+
+			// Remove edges which are already used by found paths which share root path
+			removedEdges := mapset.NewSet[string]()
+			for _, path := range paths {
+				if len(path) > i+1 && equalPaths(path[:i+1], rootPath) {
+					removedEdges.Add(edgeKey(path[i], path[i+1]))
+				}
+			}
+
+			// Remove tables of root path so that path doesn't have loop
+			removedTables := mapset.NewSet[string](rootPath[:i]...)
+
+			spurPath := e.shortestPath(spurTable, toTable, removedTables, removedEdges)
+			if spurPath == nil {
+				continue
+			}
+
+			candidate := append(append([]string{}, rootPath[:i]...), spurPath...)
+			if !containsPath(paths, candidate) && !containsPath(candidates, candidate) {
+				candidates = append(candidates, candidate)
+			}
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+
+		sortPaths(candidates)
+		paths = append(paths, candidates[0])
+		candidates = candidates[1:]
+	}
+
+	return paths
+}
+
+// shortestPath returns a shortest path which doesn't pass removedTables and removedEdges. This returns nil when path isn't found
+func (e *SchemaExplorer) shortestPath(fromTable string, toTable string, removedTables mapset.Set[string], removedEdges mapset.Set[string]) []string {
+	parents := map[string]string{fromTable: ""}
+	queue := []string{fromTable}
+
+	for len(queue) > 0 {
+		tableName := queue[0]
+		queue = queue[1:]
+
+		if tableName == toTable {
+			var path []string
+			for current := toTable; current != fromTable; current = parents[current] {
+				path = append([]string{current}, path...)
+			}
+			return append([]string{fromTable}, path...)
+		}
+
+		for _, next := range e.graph.GetRowColumns(tableName) {
+			if _, ok := parents[next]; ok || removedTables.Contains(next) || removedEdges.Contains(edgeKey(tableName, next)) {
+				continue
+			}
+			parents[next] = tableName
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
+
+// edgeKey returns key of undirected edge
+func edgeKey(a string, b string) string {
+	if a > b {
+		a, b = b, a
+	}
+	return a + "\x00" + b
+}
+
+// sortPaths sorts paths in ascending order of length, and paths which have the same length are sorted by table names
+func sortPaths(paths [][]string) {
+	sort.SliceStable(paths, func(i, j int) bool {
+		if len(paths[i]) != len(paths[j]) {
+			return len(paths[i]) < len(paths[j])
+		}
+		for k := range paths[i] {
+			if paths[i][k] != paths[j][k] {
+				return paths[i][k] < paths[j][k]
+			}
+		}
+		return false
+	})
+}
+
+func equalPaths(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsPath(paths [][]string, path []string) bool {
+	for _, p := range paths {
+		if equalPaths(p, path) {
+			return true
+		}
 	}
+	return false
 }
@@ -0,0 +1,202 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func pathTestSchema() *Schema {
+	return NewSchema([]*Table{
+		{
+			Name: "customers",
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "region_id", ToTable: "regions", ToColumn: "id"},
+			},
+		},
+		{
+			Name: "invoices",
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "order_id", ToTable: "orders", ToColumn: "id"},
+				{FromColumn: "shipment_id", ToTable: "shipments", ToColumn: "id"},
+			},
+		},
+		{
+			Name: "orders",
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "customer_id", ToTable: "customers", ToColumn: "id"},
+				{FromColumn: "warehouse_id", ToTable: "warehouses", ToColumn: "id"},
+			},
+		},
+		{
+			Name: "regions",
+		},
+		{
+			Name: "shipments",
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "order_id", ToTable: "orders", ToColumn: "id"},
+				{FromColumn: "warehouse_id", ToTable: "warehouses", ToColumn: "id"},
+			},
+		},
+		{
+			Name: "users",
+		},
+		{
+			Name: "warehouses",
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "region_id", ToTable: "regions", ToColumn: "id"},
+			},
+		},
+	})
+}
+
+func TestSchemaExplorer_Explore(t *testing.T) {
+	tests := []struct {
+		name      string
+		tableName string
+		distance  int
+		want      []string
+	}{
+		{
+			name:      "distance 0",
+			tableName: "invoices",
+			distance:  0,
+			want:      []string{"invoices"},
+		},
+		{
+			name:      "distance 1",
+			tableName: "invoices",
+			distance:  1,
+			want:      []string{"invoices", "orders", "shipments"},
+		},
+		{
+			// regions is reached via customers even if warehouses is found via longer path (orders -> shipments -> warehouses) at first
+			name:      "distance 2",
+			tableName: "orders",
+			distance:  2,
+			want:      []string{"customers", "invoices", "orders", "regions", "shipments", "warehouses"},
+		},
+		{
+			name:      "unrelated table",
+			tableName: "users",
+			distance:  1,
+			want:      []string{"users"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			explorer := NewSchemaExplorer(pathTestSchema())
+
+			got := explorer.Explore(tt.tableName, tt.distance)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSchemaExplorer_Explore_withShortcut(t *testing.T) {
+	// a -> b -> c -> x is found before a -> x, but y is within a distance of 2 from a
+	s := NewSchema([]*Table{
+		{Name: "a", ForeignKeys: []*ForeignKey{{FromColumn: "b_id", ToTable: "b", ToColumn: "id"}, {FromColumn: "x_id", ToTable: "x", ToColumn: "id"}}},
+		{Name: "b", ForeignKeys: []*ForeignKey{{FromColumn: "c_id", ToTable: "c", ToColumn: "id"}}},
+		{Name: "c", ForeignKeys: []*ForeignKey{{FromColumn: "x_id", ToTable: "x", ToColumn: "id"}}},
+		{Name: "x", ForeignKeys: []*ForeignKey{{FromColumn: "y_id", ToTable: "y", ToColumn: "id"}}},
+		{Name: "y"},
+	})
+	explorer := NewSchemaExplorer(s)
+
+	assert.Equal(t, []string{"a", "b", "c", "x", "y"}, explorer.Explore("a", 2))
+	assert.Equal(t, []string{"a", "b", "c", "x", "y"}, explorer.Explore("a", 3))
+}
+
+func TestSchemaExplorer_Explore_withCaseInsensitiveTableName(t *testing.T) {
+	// e.g. Prisma relation refers model name instead of table name
+	s := NewSchema([]*Table{
+		{Name: "posts", ForeignKeys: []*ForeignKey{{FromColumn: "author_id", ToTable: "User", ToColumn: "id"}}},
+		{Name: "user"},
+	})
+	explorer := NewSchemaExplorer(s)
+
+	assert.Equal(t, []string{"posts", "user"}, explorer.Explore("posts", 1))
+	assert.Equal(t, []string{"posts", "user"}, explorer.Explore("user", 1))
+}
+
+func TestSchemaExplorer_Distances(t *testing.T) {
+	explorer := NewSchemaExplorer(pathTestSchema())
+
+	got := explorer.Distances("invoices")
+
+	assert.Equal(t, map[string]int{
+		"customers":  2,
+		"invoices":   0,
+		"orders":     1,
+		"regions":    3,
+		"shipments":  1,
+		"warehouses": 2,
+	}, got)
+}
+
+func TestSchemaExplorer_ShortestPaths(t *testing.T) {
+	type args struct {
+		fromTable string
+		toTable   string
+		limit     int
+	}
+	tests := []struct {
+		name string
+		args args
+		want [][]string
+	}{
+		{
+			name: "all shortest paths",
+			args: args{fromTable: "invoices", toTable: "warehouses", limit: 0},
+			want: [][]string{
+				{"invoices", "orders", "warehouses"},
+				{"invoices", "shipments", "warehouses"},
+			},
+		},
+		{
+			name: "reverse direction",
+			args: args{fromTable: "warehouses", toTable: "invoices", limit: 0},
+			want: [][]string{
+				{"warehouses", "orders", "invoices"},
+				{"warehouses", "shipments", "invoices"},
+			},
+		},
+		{
+			name: "k shortest paths",
+			args: args{fromTable: "invoices", toTable: "warehouses", limit: 4},
+			want: [][]string{
+				{"invoices", "orders", "warehouses"},
+				{"invoices", "shipments", "warehouses"},
+				{"invoices", "orders", "shipments", "warehouses"},
+				{"invoices", "shipments", "orders", "warehouses"},
+			},
+		},
+		{
+			name: "limit is greater than number of paths",
+			args: args{fromTable: "customers", toTable: "regions", limit: 100},
+			want: [][]string{
+				{"customers", "regions"},
+				{"customers", "orders", "warehouses", "regions"},
+				{"customers", "orders", "shipments", "warehouses", "regions"},
+				{"customers", "orders", "invoices", "shipments", "warehouses", "regions"},
+			},
+		},
+		{
+			name: "unreachable",
+			args: args{fromTable: "invoices", toTable: "users", limit: 0},
+			want: nil,
+		},
+		{
+			name: "unreachable with limit",
+			args: args{fromTable: "invoices", toTable: "users", limit: 3},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewSchemaExplorer(pathTestSchema())
+			assert.Equal(t, tt.want, e.ShortestPaths(tt.args.fromTable, tt.args.toTable, tt.args.limit))
+		})
+	}
+}
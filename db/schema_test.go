@@ -11,7 +11,8 @@ func TestSchema_ToErd(t *testing.T) {
 		Tables []*Table
 	}
 	type args struct {
-		showIndex bool
+		showIndex   bool
+		showTrigger bool
 	}
 	tests := []struct {
 		name   string
@@ -179,6 +180,54 @@ articles }-- users`,
   * user_id : integer
 }`,
 		},
+		{
+			name: "with trigger",
+			fields: fields{
+				Tables: []*Table{
+					{
+						Name: "audit_logs",
+						Columns: []*Column{
+							{
+								Name: "user_id",
+								Type: "integer",
+							},
+						},
+					},
+					{
+						Name: "users",
+						Columns: []*Column{
+							{
+								Name:       "id",
+								Type:       "integer",
+								NotNull:    true,
+								PrimaryKey: true,
+							},
+						},
+						Triggers: []*Trigger{
+							{
+								Name:   "users_audit",
+								Event:  "INSERT",
+								Timing: "AFTER",
+								Body:   "BEGIN INSERT INTO audit_logs (user_id) VALUES (NEW.id); END",
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				showIndex:   true,
+				showTrigger: true,
+			},
+			want: `entity audit_logs {
+  user_id : integer
+}
+
+entity users {
+  * id : integer
+}
+
+users ..> audit_logs : users_audit`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -186,7 +235,7 @@ articles }-- users`,
 				Tables: tt.fields.Tables,
 			}
 
-			got := s.ToErd(tt.args.showIndex)
+			got := s.ToErd(DiagramOption{ShowIndex: tt.args.showIndex, ShowTrigger: tt.args.showTrigger})
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -198,6 +247,7 @@ func TestSchema_ToMermaid(t *testing.T) {
 	}
 	type args struct {
 		showComment bool
+		showTrigger bool
 	}
 	tests := []struct {
 		name   string
@@ -366,6 +416,56 @@ articles {
   integer user_id FK "not null"
 }`,
 		},
+		{
+			name: "with trigger",
+			fields: fields{
+				Tables: []*Table{
+					{
+						Name: "audit_logs",
+						Columns: []*Column{
+							{
+								Name: "user_id",
+								Type: "integer",
+							},
+						},
+					},
+					{
+						Name: "users",
+						Columns: []*Column{
+							{
+								Name:       "id",
+								Type:       "integer",
+								NotNull:    true,
+								PrimaryKey: true,
+							},
+						},
+						Triggers: []*Trigger{
+							{
+								Name:   "users_audit",
+								Event:  "INSERT",
+								Timing: "AFTER",
+								Body:   "BEGIN INSERT INTO audit_logs (user_id) VALUES (NEW.id); END",
+							},
+						},
+					},
+				},
+			},
+			args: args{
+				showComment: true,
+				showTrigger: true,
+			},
+			want: `erDiagram
+
+audit_logs {
+  integer user_id
+}
+
+users {
+  integer id PK "not null"
+}
+
+users }o..o{ audit_logs : "users_audit"`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -373,7 +473,93 @@ articles {
 				Tables: tt.fields.Tables,
 			}
 
-			got := s.ToMermaid(tt.args.showComment)
+			got := s.ToMermaid(DiagramOption{ShowComment: tt.args.showComment, ShowTrigger: tt.args.showTrigger})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSchema_ToD2(t *testing.T) {
+	tables := []*Table{
+		{
+			Name: "articles",
+			Columns: []*Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+				{Name: "user_id", Type: "integer", NotNull: true},
+				{Name: "slug", Type: "character varying(255)"},
+			},
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+				{FromColumn: "user_id", ToTable: "deleted_users", ToColumn: "id"},
+			},
+			Indexes: []*Index{
+				{Name: "index_slug_on_articles", Columns: []string{"slug"}, Unique: true},
+			},
+		},
+		{
+			Name: "users",
+			Columns: []*Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+				{Name: "name", Type: "text"},
+			},
+			Triggers: []*Trigger{
+				{Name: "log_users", Body: "INSERT INTO articles (user_id) VALUES (NEW.id)"},
+			},
+		},
+	}
+
+	type args struct {
+		showTrigger bool
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "without trigger",
+			args: args{},
+			want: `articles: {
+  shape: sql_table
+  id: "integer" {constraint: primary_key}
+  user_id: "integer" {constraint: foreign_key}
+  slug: "character varying(255)" {constraint: unique}
+}
+
+users: {
+  shape: sql_table
+  id: "integer" {constraint: primary_key}
+  name: "text"
+}
+
+articles.user_id -> users.id`,
+		},
+		{
+			name: "with trigger",
+			args: args{showTrigger: true},
+			want: `articles: {
+  shape: sql_table
+  id: "integer" {constraint: primary_key}
+  user_id: "integer" {constraint: foreign_key}
+  slug: "character varying(255)" {constraint: unique}
+}
+
+users: {
+  shape: sql_table
+  id: "integer" {constraint: primary_key}
+  name: "text"
+}
+
+articles.user_id -> users.id
+
+users -> articles: "log_users" {style.stroke-dash: 3}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSchema(tables)
+
+			got := s.ToD2(tt.args.showTrigger)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -617,3 +803,224 @@ func TestSchema_Subset(t *testing.T) {
 		})
 	}
 }
+
+func TestSchema_CollapsePartitions(t *testing.T) {
+	events := &Table{
+		Name: "public.events",
+		Columns: []*Column{
+			{
+				Name:    "created_at",
+				Type:    "timestamp without time zone",
+				NotNull: true,
+			},
+		},
+		PartitionKey: "RANGE (created_at)",
+	}
+
+	events202401 := &Table{
+		Name: "public.events_2024_01",
+		Columns: []*Column{
+			{
+				Name:    "created_at",
+				Type:    "timestamp without time zone",
+				NotNull: true,
+			},
+		},
+		PartitionOf: "public.events",
+	}
+
+	orphan := &Table{
+		Name:        "public.logs_2024_01",
+		PartitionOf: "public.logs",
+	}
+
+	eventLogs := &Table{
+		Name: "public.event_logs",
+		ForeignKeys: []*ForeignKey{
+			{
+				FromColumn: "event_id",
+				ToTable:    "public.events_2024_01",
+				ToColumn:   "id",
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		schema *Schema
+		want   *Schema
+	}{
+		{
+			name:   "without partitions",
+			schema: NewSchema([]*Table{events}),
+			want:   NewSchema([]*Table{events}),
+		},
+		{
+			name:   "with partitions",
+			schema: NewSchema([]*Table{eventLogs, events, events202401, orphan}),
+			want: NewSchema([]*Table{
+				{
+					Name: "public.event_logs",
+					ForeignKeys: []*ForeignKey{
+						{
+							FromColumn: "event_id",
+							ToTable:    "public.events",
+							ToColumn:   "id",
+						},
+					},
+				},
+				events,
+				orphan,
+			}),
+		},
+		{
+			name: "with sub-partitions and stats",
+			schema: NewSchema([]*Table{
+				{Name: "public.events", PartitionKey: "RANGE (created_at)", Stats: &TableStats{Rows: 0, Size: 0}},
+				{Name: "public.events_2024", PartitionKey: "LIST (kind)", PartitionOf: "public.events", Stats: &TableStats{Rows: 0}},
+				{Name: "public.events_2024_click", PartitionOf: "public.events_2024", Stats: &TableStats{Rows: 100, Size: 8192}},
+				{Name: "public.events_2024_view", PartitionOf: "public.events_2024", Stats: &TableStats{Rows: 50, Size: 4096}},
+				{
+					Name: "public.event_logs",
+					ForeignKeys: []*ForeignKey{
+						{FromColumn: "event_id", ToTable: "public.events_2024_click", ToColumn: "id"},
+					},
+				},
+			}),
+			want: NewSchema([]*Table{
+				{Name: "public.events", PartitionKey: "RANGE (created_at)", Stats: &TableStats{Rows: 150, Size: 12288}},
+				{
+					Name: "public.event_logs",
+					ForeignKeys: []*ForeignKey{
+						{FromColumn: "event_id", ToTable: "public.events", ToColumn: "id"},
+					},
+				},
+			}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.schema.CollapsePartitions()
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSchema_PartitionRoot(t *testing.T) {
+	s := NewSchema([]*Table{
+		{Name: "public.events", PartitionKey: "RANGE (created_at)"},
+		{Name: "public.events_2024", PartitionKey: "LIST (kind)", PartitionOf: "public.events"},
+		{Name: "public.events_2024_click", PartitionOf: "public.events_2024"},
+		{Name: "public.users"},
+	})
+
+	assert.Equal(t, "public.events", s.PartitionRoot("public.events_2024_click"))
+	assert.Equal(t, "public.events", s.PartitionRoot("public.events_2024"))
+	assert.Equal(t, "public.events", s.PartitionRoot("public.events"))
+	assert.Equal(t, "public.users", s.PartitionRoot("public.users"))
+}
+
+func TestSchema_ToErd_withRelationOption(t *testing.T) {
+	s := NewSchema([]*Table{
+		{
+			Name: "orders",
+			Columns: []*Column{
+				{Name: "buyer_id", Type: "integer"},
+				{Name: "seller_id", Type: "integer"},
+			},
+			ForeignKeys: []*ForeignKey{
+				{FromColumn: "buyer_id", ToTable: "users", ToColumn: "id", Name: "fk_orders_buyer"},
+				{FromColumn: "seller_id", ToTable: "users", ToColumn: "id", Name: "fk_orders_seller"},
+			},
+		},
+		{
+			Name:    "users",
+			Columns: []*Column{{Name: "id", Type: "integer"}},
+		},
+	})
+
+	tests := []struct {
+		name           string
+		relationOption RelationOption
+		wantErd        string
+		wantMermaid    string
+	}{
+		{
+			name:           "no label",
+			relationOption: RelationOption{},
+			wantErd:        "orders }-- users\n\norders }-- users",
+			wantMermaid:    "users ||--o{ orders : owns\n\nusers ||--o{ orders : owns",
+		},
+		{
+			name:           "column",
+			relationOption: RelationOption{Label: "column"},
+			wantErd:        "orders }-- users : buyer_id\n\norders }-- users : seller_id",
+			wantMermaid:    "users ||--o{ orders : \"buyer_id\"\n\nusers ||--o{ orders : \"seller_id\"",
+		},
+		{
+			name:           "name",
+			relationOption: RelationOption{Label: "name"},
+			wantErd:        "orders }-- users : fk_orders_buyer\n\norders }-- users : fk_orders_seller",
+			wantMermaid:    "users ||--o{ orders : \"fk_orders_buyer\"\n\nusers ||--o{ orders : \"fk_orders_seller\"",
+		},
+		{
+			name:           "link column",
+			relationOption: RelationOption{LinkColumn: true},
+			wantErd:        "orders::buyer_id }-- users::id\n\norders::seller_id }-- users::id",
+			wantMermaid:    "users ||--o{ orders : owns\n\nusers ||--o{ orders : owns",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Contains(t, s.ToErd(DiagramOption{Relation: tt.relationOption}), tt.wantErd)
+			assert.Contains(t, s.ToMermaid(DiagramOption{Relation: tt.relationOption}), tt.wantMermaid)
+		})
+	}
+}
+
+func TestSchema_PathSubset(t *testing.T) {
+	s := pathTestSchema()
+	paths := NewSchemaExplorer(s).ShortestPaths("invoices", "warehouses", 0)
+
+	want := `erDiagram
+
+invoices
+
+orders
+
+shipments
+
+warehouses
+
+orders ||--o{ invoices : "order_id = orders.id"
+
+shipments ||--o{ invoices : "shipment_id = shipments.id"
+
+warehouses ||--o{ orders : "warehouse_id = warehouses.id"
+
+warehouses ||--o{ shipments : "warehouse_id = warehouses.id"`
+
+	got := s.PathSubset(paths).ToMermaid(DiagramOption{Relation: RelationOption{Label: "join"}})
+	assert.Equal(t, want, got)
+
+	// original schema isn't modified
+	assert.Len(t, s.findTable("orders").ForeignKeys, 2)
+}
+
+func TestSchema_PathSubset_withCaseInsensitiveTableName(t *testing.T) {
+	// e.g. Oracle, MySQL with lower_case_table_names
+	s := NewSchema([]*Table{
+		{
+			Name:        "ARTICLES",
+			ForeignKeys: []*ForeignKey{{FromColumn: "USER_ID", ToTable: "users", ToColumn: "ID"}},
+		},
+		{Name: "LOGS"},
+		{Name: "USERS"},
+	})
+	paths := NewSchemaExplorer(s).ShortestPaths("ARTICLES", "USERS", 0)
+
+	got := s.PathSubset(paths)
+
+	assert.Equal(t, [][]string{{"ARTICLES", "USERS"}}, paths)
+	assert.Equal(t, NewSchema([]*Table{s.Tables[0], s.Tables[2]}), got)
+}
@@ -0,0 +1,199 @@
+package db
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+const (
+	svgHeaderHeight = 28
+	svgRowHeight    = 20
+	svgKeyWidth     = 28
+	svgPadding      = 8
+	svgMinWidth     = 120
+
+	// svgRouteMargin represents extra left margin for relations which go around left side of tables
+	svgRouteMargin = 60
+
+	// svgCharWidth represents approximate width of a character of 12px monospace font
+	svgCharWidth = 7.2
+
+	svgStyle = `text { font-family: monospace; font-size: 12px; fill: #222; }
+.table rect { fill: #fff; stroke: #444; }
+.table .header { fill: #e8eef7; }
+.table .name { font-weight: bold; }
+.table .key { font-weight: bold; fill: #a35200; }
+.relation { fill: none; stroke: #555; }
+.relation.inferred { stroke-dasharray: 6 4; }
+.trigger { fill: none; stroke: #888; stroke-dasharray: 4 3; }
+.trigger-label { fill: #666; font-size: 11px; }`
+
+	// svgMarkers represents crow's foot notations. +x of marker points to the entity (orient="auto" at the end of path, orient="auto-start-reverse" at the start of path)
+	svgMarkers = `<marker id="one" viewBox="-14 -8 16 16" refX="0" refY="0" markerWidth="16" markerHeight="16" markerUnits="userSpaceOnUse" orient="auto">
+  <path d="M -6 -6 L -6 6 M -10 -6 L -10 6" stroke="#555" fill="none" />
+</marker>
+<marker id="one-or-many" viewBox="-18 -8 20 16" refX="0" refY="0" markerWidth="20" markerHeight="16" markerUnits="userSpaceOnUse" orient="auto-start-reverse">
+  <path d="M 0 -6 L -10 0 L 0 6 M 0 0 L -10 0 M -14 -6 L -14 6" stroke="#555" fill="none" />
+</marker>
+<marker id="zero-or-many" viewBox="-22 -8 24 16" refX="0" refY="0" markerWidth="24" markerHeight="16" markerUnits="userSpaceOnUse" orient="auto-start-reverse">
+  <path d="M 0 -6 L -10 0 L 0 6 M 0 0 L -10 0" stroke="#555" fill="none" />
+  <circle cx="-15" cy="0" r="4" stroke="#555" fill="#fff" />
+</marker>
+<marker id="arrow" viewBox="-12 -6 14 12" refX="0" refY="0" markerWidth="14" markerHeight="12" markerUnits="userSpaceOnUse" orient="auto">
+  <path d="M -10 -5 L 0 0 L -10 5" stroke="#888" fill="none" />
+</marker>`
+)
+
+// ToSVG returns SVG image of schema. Tables are placed with layered layout and foreign keys are drawn with crow's foot notation
+func (s *Schema) ToSVG(showTrigger bool) string {
+	boxes := layoutTables(s.Tables, svgTableSize)
+
+	boxesByName := map[string]*tableBox{}
+	width, height := 0.0, 0.0
+	for _, box := range boxes {
+		boxesByName[box.table.Name] = box
+		width = max(width, box.x+box.width+layoutMarginX)
+		height = max(height, box.y+box.height+layoutMarginY)
+	}
+
+	lines := []string{
+		fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%g" height="%g" viewBox="%d 0 %g %g">`, width+svgRouteMargin, height, -svgRouteMargin, width+svgRouteMargin, height),
+		"<style>",
+		svgStyle,
+		"</style>",
+		"<defs>",
+		svgMarkers,
+		"</defs>",
+	}
+
+	for _, box := range boxes {
+		lines = append(lines, box.toSVG())
+	}
+
+	for _, table := range s.Tables {
+		from := boxesByName[table.Name]
+
+		for _, foreignKey := range table.ForeignKeys {
+			to, ok := boxesByName[strings.ToLower(foreignKey.ToTable)]
+			if !ok {
+				continue
+			}
+
+			startMarker := "zero-or-many"
+			if column := table.findColumn(foreignKey.FromColumn); column != nil && column.NotNull {
+				startMarker = "one-or-many"
+			}
+
+			class := "relation"
+			if foreignKey.Inferred {
+				class += " inferred"
+			}
+
+			path := svgPath(from, from.columnY(foreignKey.FromColumn), to, to.columnY(foreignKey.ToColumn))
+			lines = append(lines, fmt.Sprintf(`<path class="%s" d="%s" marker-start="url(#%s)" marker-end="url(#one)" />`, class, path, startMarker))
+		}
+	}
+
+	if showTrigger {
+		for _, relation := range s.triggerRelations() {
+			from := boxesByName[relation.fromTable]
+			to := boxesByName[relation.toTable]
+
+			fromY := from.y + svgHeaderHeight/2
+			toY := to.y + svgHeaderHeight/2
+			path := svgPath(from, fromY, to, toY)
+
+			lines = append(lines,
+				fmt.Sprintf(`<path class="trigger" d="%s" marker-end="url(#arrow)" />`, path),
+				fmt.Sprintf(`<text class="trigger-label" x="%g" y="%g" text-anchor="middle">%s</text>`, (from.x+from.width/2+to.x+to.width/2)/2, (fromY+toY)/2-4, xmlEscape(relation.trigger.Name)),
+			)
+		}
+	}
+
+	lines = append(lines, "</svg>")
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// toSVG returns SVG group of table box
+func (b *tableBox) toSVG() string {
+	lines := []string{
+		fmt.Sprintf(`<g class="table" transform="translate(%g %g)">`, b.x, b.y),
+		fmt.Sprintf(`  <rect width="%g" height="%g" />`, b.width, b.height),
+		fmt.Sprintf(`  <rect class="header" width="%g" height="%d" />`, b.width, svgHeaderHeight),
+		fmt.Sprintf(`  <text class="name" x="%g" y="%d" text-anchor="middle">%s</text>`, b.width/2, svgHeaderHeight/2+4, xmlEscape(b.table.Name)),
+	}
+
+	for i, column := range b.table.Columns {
+		y := svgHeaderHeight + i*svgRowHeight + svgRowHeight/2 + 4
+
+		if key := b.table.columnKey(column); key != "" {
+			lines = append(lines, fmt.Sprintf(`  <text class="key" x="%d" y="%d">%s</text>`, svgPadding, y, key))
+		}
+
+		lines = append(lines, fmt.Sprintf(`  <text x="%d" y="%d">%s</text>`, svgPadding+svgKeyWidth, y, xmlEscape(svgColumnLabel(column))))
+	}
+
+	lines = append(lines, "</g>")
+	return strings.Join(lines, "\n")
+}
+
+// columnY returns y coordinate of center of column row. This returns center of header when column isn't found
+func (b *tableBox) columnY(columnName string) float64 {
+	for i, column := range b.table.Columns {
+		if column.Name == columnName {
+			return b.y + svgHeaderHeight + float64(i)*svgRowHeight + svgRowHeight/2
+		}
+	}
+	return b.y + svgHeaderHeight/2
+}
+
+// svgPath returns bezier curve between side of from and side of to
+func svgPath(from *tableBox, fromY float64, to *tableBox, toY float64) string {
+	const offset = 40
+
+	var x1, x2, c1, c2 float64
+
+	switch {
+	case from.x+from.width < to.x:
+		// from is left of to
+		x1, x2 = from.x+from.width, to.x
+		dx := max((x2-x1)/2, offset)
+		c1, c2 = x1+dx, x2-dx
+	case to.x+to.width < from.x:
+		// from is right of to
+		x1, x2 = from.x, to.x+to.width
+		dx := max((x1-x2)/2, offset)
+		c1, c2 = x1-dx, x2+dx
+	default:
+		// Overlapping horizontally (e.g. stacked vertically or self reference). Go around left side.
+		// Longer relation goes further so that relations don't overlap each other
+		x1, x2 = from.x, to.x
+		c1 = math.Min(x1, x2) - offset - math.Min(math.Abs(toY-fromY)/8, svgRouteMargin)
+		c2 = c1
+	}
+
+	return fmt.Sprintf("M %g %g C %g %g, %g %g, %g %g", x1, fromY, c1, fromY, c2, toY, x2, toY)
+}
+
+// svgTableSize returns width and height of table box
+func svgTableSize(table *Table) (float64, float64) {
+	length := 0
+	for _, column := range table.Columns {
+		length = max(length, len(svgColumnLabel(column)))
+	}
+
+	width := max(svgMinWidth, float64(len(table.Name))*svgCharWidth+svgPadding*2, float64(length)*svgCharWidth+svgPadding*2+svgKeyWidth)
+	height := svgHeaderHeight + len(table.Columns)*svgRowHeight
+
+	return math.Ceil(width), float64(height)
+}
+
+func svgColumnLabel(column *Column) string {
+	label := fmt.Sprintf("%s : %s", column.Name, column.Type)
+	if column.NotNull && !column.PrimaryKey {
+		label = "* " + label
+	}
+	return label
+}
@@ -0,0 +1,101 @@
+package db
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type svgImage struct {
+	Groups []struct {
+		Transform string   `xml:"transform,attr"`
+		Texts     []string `xml:"text"`
+	} `xml:"g"`
+	Paths []struct {
+		Class       string `xml:"class,attr"`
+		D           string `xml:"d,attr"`
+		MarkerStart string `xml:"marker-start,attr"`
+		MarkerEnd   string `xml:"marker-end,attr"`
+	} `xml:"path"`
+	Texts []string `xml:"text"`
+}
+
+func TestSchema_ToSVG(t *testing.T) {
+	s := markdownTestSchema()
+	s.Tables[0].Triggers = []*Trigger{
+		{Name: "touch_users", Body: "UPDATE users SET updated_at = now()"},
+	}
+
+	t.Run("tables and foreign keys", func(t *testing.T) {
+		var image svgImage
+		err := xml.Unmarshal([]byte(s.ToSVG(false)), &image)
+		require.NoError(t, err)
+
+		if assert.Len(t, image.Groups, 2) {
+			assert.Equal(t, "translate(40 188)", image.Groups[0].Transform)
+			assert.Equal(t, []string{"articles", "PK", "id : integer", "FK", "* user_id : integer", "status : text"}, image.Groups[0].Texts)
+			assert.Equal(t, "translate(40 40)", image.Groups[1].Transform)
+			assert.Equal(t, []string{"users", "PK", "id : integer"}, image.Groups[1].Texts[:3])
+		}
+
+		if assert.Len(t, image.Paths, 1) {
+			// articles.user_id -> users.id
+			assert.Equal(t, "relation", image.Paths[0].Class)
+			assert.Equal(t, "M 40 246 C -21 246, -21 78, 40 78", image.Paths[0].D)
+			assert.Equal(t, "url(#one-or-many)", image.Paths[0].MarkerStart)
+			assert.Equal(t, "url(#one)", image.Paths[0].MarkerEnd)
+		}
+
+		assert.Empty(t, image.Texts)
+	})
+
+	t.Run("with trigger", func(t *testing.T) {
+		var image svgImage
+		err := xml.Unmarshal([]byte(s.ToSVG(true)), &image)
+		require.NoError(t, err)
+
+		if assert.Len(t, image.Paths, 2) {
+			assert.Equal(t, "trigger", image.Paths[1].Class)
+			assert.Equal(t, "url(#arrow)", image.Paths[1].MarkerEnd)
+		}
+		assert.Equal(t, []string{"touch_users"}, image.Texts)
+	})
+}
+
+func Test_svgPath(t *testing.T) {
+	left := &tableBox{x: 40, y: 40, width: 100, height: 100}
+	right := &tableBox{x: 300, y: 40, width: 100, height: 100}
+
+	tests := []struct {
+		name string
+		from *tableBox
+		to   *tableBox
+		want string
+	}{
+		{
+			name: "left to right",
+			from: left,
+			to:   right,
+			want: "M 140 50 C 220 50, 220 90, 300 90",
+		},
+		{
+			name: "right to left",
+			from: right,
+			to:   left,
+			want: "M 300 50 C 220 50, 220 90, 140 90",
+		},
+		{
+			name: "self reference",
+			from: left,
+			to:   left,
+			want: "M 40 50 C -5 50, -5 90, 40 90",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, svgPath(tt.from, 50, tt.to, 90))
+		})
+	}
+}
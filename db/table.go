@@ -11,16 +11,41 @@ type Table struct {
 	Columns     []*Column
 	ForeignKeys []*ForeignKey
 	Indexes     []*Index
+	Triggers    []*Trigger
+
+	// PartitionKey represents partitioning method and key when table is partitioned (e.g. RANGE (created_at))
+	PartitionKey string
+
+	// PartitionOf represents parent table name when table is a partition of another table
+	PartitionOf string
+
+	// Stats represents table statistics. This is nil when statistics aren't loaded
+	Stats *TableStats
+
+	// Group represents name of group which table belongs to (e.g. schema name). Tables of the same group are wrapped in a block
+	Group string
+
+	// Tags represents stereotypes of table (e.g. deprecated)
+	Tags []string
+
+	// Color represents background color of table in PlantUML (e.g. #FFCCCC)
+	Color string
 }
 
-// ToErd returns ERD formatted table
-func (t *Table) ToErd(showIndex bool) string {
+// ToErd returns ERD formatted table. columnMode is one of all (default), keys (only primary key and foreign key columns) and none
+func (t *Table) ToErd(showIndex bool, columnMode string) string {
 	lines := []string{
-		fmt.Sprintf("entity %s {", t.Name),
+		fmt.Sprintf("entity %s {", t.erdHeader()),
 	}
 
-	pkColumns := t.GetPrimaryKeyColumns()
-	nonPkColumns := t.GetNonPrimaryKeyColumns()
+	var pkColumns, nonPkColumns []*Column
+	for _, column := range t.visibleColumns(columnMode) {
+		if column.PrimaryKey {
+			pkColumns = append(pkColumns, column)
+		} else {
+			nonPkColumns = append(nonPkColumns, column)
+		}
+	}
 
 	var area []string
 
@@ -40,7 +65,7 @@ func (t *Table) ToErd(showIndex bool) string {
 		area = append(area, strings.Join(parts, "\n"))
 	}
 
-	if showIndex && len(t.Indexes) > 0 {
+	if showIndex && columnMode != "none" && len(t.Indexes) > 0 {
 		var parts []string
 		for _, index := range t.Indexes {
 			parts = append(parts, "  "+index.ToErd())
@@ -48,12 +73,49 @@ func (t *Table) ToErd(showIndex bool) string {
 		area = append(area, strings.Join(parts, "\n"))
 	}
 
-	lines = append(lines, strings.Join(area, "\n  --\n"))
+	if len(area) > 0 {
+		lines = append(lines, strings.Join(area, "\n  --\n"))
+	}
 
 	lines = append(lines, "}")
 	return strings.Join(lines, "\n")
 }
 
+func (t *Table) erdHeader() string {
+	parts := []string{t.Name}
+
+	if t.PartitionKey != "" {
+		parts = append(parts, fmt.Sprintf("<<partitioned by %s>>", t.PartitionKey))
+	}
+
+	if t.PartitionOf != "" {
+		parts = append(parts, fmt.Sprintf("<<partition of %s>>", t.PartitionOf))
+	}
+
+	if t.Stats != nil {
+		parts = append(parts, fmt.Sprintf("<<%s>>", t.Stats.String()))
+	}
+
+	parts = append(parts, t.plantUmlTags()...)
+
+	return strings.Join(parts, " ")
+}
+
+// plantUmlTags returns stereotypes and color of table (e.g. <<deprecated>> #FFCCCC)
+func (t *Table) plantUmlTags() []string {
+	var parts []string
+
+	for _, tag := range t.Tags {
+		parts = append(parts, fmt.Sprintf("<<%s>>", tag))
+	}
+
+	if t.Color != "" {
+		parts = append(parts, t.Color)
+	}
+
+	return parts
+}
+
 // GetPrimaryKeyColumns returns Primary key columns
 func (t *Table) GetPrimaryKeyColumns() []*Column {
 	var columns []*Column
@@ -76,18 +138,42 @@ func (t *Table) GetNonPrimaryKeyColumns() []*Column {
 	return columns
 }
 
-// ToMermaid returns Mermaid formatted table
-func (t *Table) ToMermaid(showComment bool) string {
+// visibleColumns returns columns which are printed with columnMode (all, keys, none)
+func (t *Table) visibleColumns(columnMode string) []*Column {
+	switch columnMode {
+	case "none":
+		return nil
+	case "keys":
+		var columns []*Column
+		for _, column := range t.Columns {
+			if t.columnKey(column) != "" {
+				columns = append(columns, column)
+			}
+		}
+		return columns
+	default:
+		return t.Columns
+	}
+}
+
+// ToMermaid returns Mermaid formatted table. columnMode is one of all (default), keys (only primary key and foreign key columns) and none
+func (t *Table) ToMermaid(showComment bool, columnMode string) string {
+	columns := t.visibleColumns(columnMode)
+	if len(columns) == 0 {
+		// Mermaid allows entity without attributes block
+		return t.Name
+	}
+
 	lines := []string{
 		fmt.Sprintf("%s {", t.Name),
 	}
 
-	for _, column := range t.Columns {
+	for _, column := range columns {
 		var parts []string
 		parts = append(parts, column.ToMermaid())
 
 		if showComment {
-			key := t.mermaidColumnKey(column)
+			key := t.columnKey(column)
 			if key != "" {
 				parts = append(parts, key)
 			}
@@ -106,7 +192,8 @@ func (t *Table) ToMermaid(showComment bool) string {
 	return strings.Join(lines, "\n")
 }
 
-func (t *Table) mermaidColumnKey(column *Column) string {
+// columnKey returns PK when column is primary key, FK when column is foreign key, otherwise empty
+func (t *Table) columnKey(column *Column) string {
 	if column.PrimaryKey {
 		return "PK"
 	}
@@ -120,6 +207,25 @@ func (t *Table) mermaidColumnKey(column *Column) string {
 	return ""
 }
 
+func (t *Table) findColumn(columnName string) *Column {
+	for _, column := range t.Columns {
+		if column.Name == columnName {
+			return column
+		}
+	}
+	return nil
+}
+
+// FindColumnIgnoreCase returns column whose name is the same as columnName case-insensitively. This returns nil when column isn't found
+func (t *Table) FindColumnIgnoreCase(columnName string) *Column {
+	for _, column := range t.Columns {
+		if strings.EqualFold(column.Name, columnName) {
+			return column
+		}
+	}
+	return nil
+}
+
 func (t *Table) mermaidColumnComment(column *Column) string {
 	parts := []string{}
 	if column.NotNull {
@@ -131,3 +237,44 @@ func (t *Table) mermaidColumnComment(column *Column) string {
 	}
 	return fmt.Sprintf("\"%s\"", strings.Join(parts, " "))
 }
+
+// ToD2 returns D2 formatted table
+func (t *Table) ToD2() string {
+	lines := []string{
+		fmt.Sprintf("%s: {", d2Key(t.Name)),
+		"  shape: sql_table",
+	}
+
+	for _, column := range t.Columns {
+		lines = append(lines, "  "+column.ToD2(t.d2ColumnConstraints(column)))
+	}
+
+	lines = append(lines, "}")
+	return strings.Join(lines, "\n")
+}
+
+func (t *Table) d2ColumnConstraints(column *Column) []string {
+	var constraints []string
+
+	if column.PrimaryKey {
+		constraints = append(constraints, "primary_key")
+	}
+
+	for _, foreignKey := range t.ForeignKeys {
+		if foreignKey.FromColumn == column.Name {
+			constraints = append(constraints, "foreign_key")
+			break
+		}
+	}
+
+	if !column.PrimaryKey {
+		for _, index := range t.Indexes {
+			if index.Unique && len(index.Columns) == 1 && len(index.Expressions()) == 0 && index.Predicate == "" && index.Columns[0] == column.Name {
+				constraints = append(constraints, "unique")
+				break
+			}
+		}
+	}
+
+	return constraints
+}
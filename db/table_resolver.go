@@ -0,0 +1,73 @@
+package db
+
+import (
+	"strings"
+)
+
+// TableResolver resolves table name of foreign key to table of schema
+type TableResolver struct {
+	tablesByName      map[string]*Table
+	tablesByLowerName map[string]*Table
+}
+
+// NewTableResolver returns a new TableResolver instance
+func NewTableResolver(tables []*Table) *TableResolver {
+	r := &TableResolver{
+		tablesByName:      map[string]*Table{},
+		tablesByLowerName: map[string]*Table{},
+	}
+
+	for _, table := range tables {
+		r.tablesByName[table.Name] = table
+
+		lowerName := strings.ToLower(table.Name)
+		if _, ok := r.tablesByLowerName[lowerName]; !ok {
+			r.tablesByLowerName[lowerName] = table
+		}
+	}
+
+	return r
+}
+
+// Find returns table whose name is the same as tableName. Table name is compared case-insensitively
+// because case of table name of foreign key may be different from table (e.g. Prisma model User, MySQL on case-insensitive file system)
+func (r *TableResolver) Find(tableName string) *Table {
+	if table, ok := r.tablesByName[tableName]; ok {
+		return table
+	}
+
+	return r.tablesByLowerName[strings.ToLower(tableName)]
+}
+
+// ResolveName returns name of table whose name is the same as tableName. This returns tableName when table isn't found (e.g. referenced table is skipped)
+func (r *TableResolver) ResolveName(tableName string) string {
+	if table := r.Find(tableName); table != nil {
+		return table.Name
+	}
+	return tableName
+}
+
+// findNear returns tables whose names are in names in order of names. Table names are compared case-insensitively.
+// Tables in the same schema as table are preferred (e.g. names of public.articles are resolved to public.users rather than users)
+func (r *TableResolver) findNear(table *Table, names []string) []*Table {
+	schemaPrefix := ""
+	if i := strings.LastIndex(table.Name, "."); i >= 0 {
+		schemaPrefix = table.Name[:i+1]
+	}
+
+	prefixes := []string{""}
+	if schemaPrefix != "" {
+		prefixes = []string{schemaPrefix, ""}
+	}
+
+	var tables []*Table
+	for _, prefix := range prefixes {
+		for _, name := range names {
+			if found, ok := r.tablesByLowerName[strings.ToLower(prefix+name)]; ok {
+				tables = append(tables, found)
+			}
+		}
+	}
+
+	return tables
+}
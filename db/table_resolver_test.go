@@ -0,0 +1,27 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableResolver_Find(t *testing.T) {
+	users := &Table{Name: "users"}
+	upperUsers := &Table{Name: "USERS"}
+	articles := &Table{Name: "public.articles"}
+	resolver := NewTableResolver([]*Table{users, upperUsers, articles})
+
+	assert.Equal(t, users, resolver.Find("users"))
+	assert.Equal(t, upperUsers, resolver.Find("USERS"))
+	assert.Equal(t, users, resolver.Find("Users"))
+	assert.Equal(t, articles, resolver.Find("PUBLIC.ARTICLES"))
+	assert.Nil(t, resolver.Find("comments"))
+}
+
+func TestTableResolver_ResolveName(t *testing.T) {
+	resolver := NewTableResolver([]*Table{{Name: "users"}})
+
+	assert.Equal(t, "users", resolver.ResolveName("USERS"))
+	assert.Equal(t, "comments", resolver.ResolveName("comments"))
+}
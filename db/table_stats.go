@@ -0,0 +1,70 @@
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// TableStats represents table statistics
+type TableStats struct {
+	// Rows represents (estimated) number of rows
+	Rows int64
+
+	// Size represents (estimated) on-disk size in bytes. 0 means unknown
+	Size int64
+}
+
+// String returns formatted statistics (e.g. "1,234 rows, 16.0 KB")
+func (s *TableStats) String() string {
+	unit := "rows"
+	if s.Rows == 1 {
+		unit = "row"
+	}
+
+	parts := []string{fmt.Sprintf("%s %s", formatNumber(s.Rows), unit)}
+
+	if s.Size > 0 {
+		parts = append(parts, formatBytes(s.Size))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func formatNumber(n int64) string {
+	str := strconv.FormatInt(n, 10)
+
+	sign := ""
+	if strings.HasPrefix(str, "-") {
+		sign = "-"
+		str = str[1:]
+	}
+
+	var parts []string
+	for len(str) > 3 {
+		parts = append([]string{str[len(str)-3:]}, parts...)
+		str = str[:len(str)-3]
+	}
+	parts = append([]string{str}, parts...)
+
+	return sign + strings.Join(parts, ",")
+}
+
+func formatBytes(size int64) string {
+	const unit = 1024
+
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	value := float64(size)
+	units := []string{"KB", "MB", "GB", "TB", "PB"}
+	for i, u := range units {
+		value /= unit
+		if value < unit || i == len(units)-1 {
+			return fmt.Sprintf("%.1f %s", value, u)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,52 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTableStats_String(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats *TableStats
+		want  string
+	}{
+		{
+			name:  "without size",
+			stats: &TableStats{Rows: 12},
+			want:  "12 rows",
+		},
+		{
+			name:  "single row",
+			stats: &TableStats{Rows: 1},
+			want:  "1 row",
+		},
+		{
+			name:  "no rows",
+			stats: &TableStats{Rows: 0},
+			want:  "0 rows",
+		},
+		{
+			name:  "with size in bytes",
+			stats: &TableStats{Rows: 1234, Size: 512},
+			want:  "1,234 rows, 512 B",
+		},
+		{
+			name:  "with size in megabytes",
+			stats: &TableStats{Rows: 1234567, Size: 16 * 1024 * 1024},
+			want:  "1,234,567 rows, 16.0 MB",
+		},
+		{
+			name:  "with size in kilobytes",
+			stats: &TableStats{Rows: 100, Size: 1536},
+			want:  "100 rows, 1.5 KB",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.stats.String()
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -8,13 +8,19 @@ import (
 
 func TestTable_ToErd(t *testing.T) {
 	type fields struct {
-		Name        string
-		Columns     []*Column
-		ForeignKeys []*ForeignKey
-		Indexes     []*Index
+		Name         string
+		Columns      []*Column
+		ForeignKeys  []*ForeignKey
+		Indexes      []*Index
+		PartitionKey string
+		PartitionOf  string
+		Stats        *TableStats
+		Tags         []string
+		Color        string
 	}
 	type args struct {
-		showIndex bool
+		showIndex  bool
+		columnMode string
 	}
 	tests := []struct {
 		name   string
@@ -199,19 +205,194 @@ func TestTable_ToErd(t *testing.T) {
   --
   * user_id : integer
   * target_user_id : integer
+}`,
+		},
+		{
+			name: "partitioned table",
+			fields: fields{
+				Name: "public.events",
+				Columns: []*Column{
+					{
+						Name:    "created_at",
+						Type:    "timestamp without time zone",
+						NotNull: true,
+					},
+				},
+				PartitionKey: "RANGE (created_at)",
+			},
+			args: args{
+				showIndex: true,
+			},
+			want: `entity public.events <<partitioned by RANGE (created_at)>> {
+  * created_at : timestamp without time zone
+}`,
+		},
+		{
+			name: "partition",
+			fields: fields{
+				Name: "public.events_2024_01",
+				Columns: []*Column{
+					{
+						Name:    "created_at",
+						Type:    "timestamp without time zone",
+						NotNull: true,
+					},
+				},
+				PartitionOf: "public.events",
+			},
+			args: args{
+				showIndex: true,
+			},
+			want: `entity public.events_2024_01 <<partition of public.events>> {
+  * created_at : timestamp without time zone
+}`,
+		},
+		{
+			name: "with stats",
+			fields: fields{
+				Name: "users",
+				Columns: []*Column{
+					{
+						Name:       "id",
+						Type:       "integer",
+						NotNull:    true,
+						PrimaryKey: true,
+					},
+				},
+				Stats: &TableStats{Rows: 1234, Size: 16384},
+			},
+			args: args{
+				showIndex: true,
+			},
+			want: `entity users <<1,234 rows, 16.0 KB>> {
+  * id : integer
+}`,
+		},
+		{
+			name: "with tags and color",
+			fields: fields{
+				Name: "legacy_users",
+				Columns: []*Column{
+					{
+						Name: "name",
+						Type: "text",
+					},
+				},
+				Tags:  []string{"deprecated", "no_pk"},
+				Color: "#FFCCCC",
+			},
+			args: args{
+				showIndex: true,
+			},
+			want: `entity legacy_users <<deprecated>> <<no_pk>> #FFCCCC {
+  name : text
+}`,
+		},
+		{
+			name: "only key columns",
+			fields: fields{
+				Name: "articles",
+				Columns: []*Column{
+					{
+						Name:       "id",
+						Type:       "integer",
+						NotNull:    true,
+						PrimaryKey: true,
+					},
+					{
+						Name:    "user_id",
+						Type:    "integer",
+						NotNull: true,
+					},
+					{
+						Name: "title",
+						Type: "text",
+					},
+				},
+				ForeignKeys: []*ForeignKey{
+					{
+						FromColumn: "user_id",
+						ToTable:    "users",
+						ToColumn:   "id",
+					},
+				},
+				Indexes: []*Index{
+					{
+						Name:    "index_title_on_articles",
+						Columns: []string{"title"},
+					},
+				},
+			},
+			args: args{
+				showIndex:  true,
+				columnMode: "keys",
+			},
+			want: `entity articles {
+  * id : integer
+  --
+  * user_id : integer
+  --
+  index_title_on_articles (title)
+}`,
+		},
+		{
+			name: "without columns",
+			fields: fields{
+				Name: "articles",
+				Columns: []*Column{
+					{
+						Name:       "id",
+						Type:       "integer",
+						NotNull:    true,
+						PrimaryKey: true,
+					},
+					{
+						Name:    "user_id",
+						Type:    "integer",
+						NotNull: true,
+					},
+					{
+						Name: "title",
+						Type: "text",
+					},
+				},
+				ForeignKeys: []*ForeignKey{
+					{
+						FromColumn: "user_id",
+						ToTable:    "users",
+						ToColumn:   "id",
+					},
+				},
+				Indexes: []*Index{
+					{
+						Name:    "index_title_on_articles",
+						Columns: []string{"title"},
+					},
+				},
+			},
+			args: args{
+				showIndex:  true,
+				columnMode: "none",
+			},
+			want: `entity articles {
 }`,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			table := &Table{
-				Name:        tt.fields.Name,
-				Columns:     tt.fields.Columns,
-				ForeignKeys: tt.fields.ForeignKeys,
-				Indexes:     tt.fields.Indexes,
+				Name:         tt.fields.Name,
+				Columns:      tt.fields.Columns,
+				ForeignKeys:  tt.fields.ForeignKeys,
+				Indexes:      tt.fields.Indexes,
+				PartitionKey: tt.fields.PartitionKey,
+				PartitionOf:  tt.fields.PartitionOf,
+				Stats:        tt.fields.Stats,
+				Tags:         tt.fields.Tags,
+				Color:        tt.fields.Color,
 			}
 
-			got := table.ToErd(tt.args.showIndex)
+			got := table.ToErd(tt.args.showIndex, tt.args.columnMode)
 			assert.Equal(t, tt.want, got)
 		})
 	}
@@ -226,6 +407,7 @@ func TestTable_ToMermaid(t *testing.T) {
 	}
 	type args struct {
 		showComment bool
+		columnMode  string
 	}
 	tests := []struct {
 		name   string
@@ -309,6 +491,91 @@ func TestTable_ToMermaid(t *testing.T) {
   text title
 }`,
 		},
+		{
+			name: "only key columns",
+			fields: fields{
+				Name: "articles",
+				Columns: []*Column{
+					{
+						Name:       "id",
+						Type:       "integer",
+						NotNull:    true,
+						PrimaryKey: true,
+					},
+					{
+						Name:    "user_id",
+						Type:    "integer",
+						NotNull: true,
+					},
+					{
+						Name: "title",
+						Type: "text",
+					},
+				},
+				ForeignKeys: []*ForeignKey{
+					{
+						FromColumn: "user_id",
+						ToTable:    "users",
+						ToColumn:   "id",
+					},
+				},
+				Indexes: []*Index{
+					{
+						Name:    "index_title_on_articles",
+						Columns: []string{"title"},
+					},
+				},
+			},
+			args: args{
+				showComment: true,
+				columnMode:  "keys",
+			},
+			want: `articles {
+  integer id PK "not null"
+  integer user_id FK "not null"
+}`,
+		},
+		{
+			name: "without columns",
+			fields: fields{
+				Name: "articles",
+				Columns: []*Column{
+					{
+						Name:       "id",
+						Type:       "integer",
+						NotNull:    true,
+						PrimaryKey: true,
+					},
+					{
+						Name:    "user_id",
+						Type:    "integer",
+						NotNull: true,
+					},
+					{
+						Name: "title",
+						Type: "text",
+					},
+				},
+				ForeignKeys: []*ForeignKey{
+					{
+						FromColumn: "user_id",
+						ToTable:    "users",
+						ToColumn:   "id",
+					},
+				},
+				Indexes: []*Index{
+					{
+						Name:    "index_title_on_articles",
+						Columns: []string{"title"},
+					},
+				},
+			},
+			args: args{
+				showComment: true,
+				columnMode:  "none",
+			},
+			want: `articles`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -319,8 +586,21 @@ func TestTable_ToMermaid(t *testing.T) {
 				Indexes:     tt.fields.Indexes,
 			}
 
-			got := table.ToMermaid(tt.args.showComment)
+			got := table.ToMermaid(tt.args.showComment, tt.args.columnMode)
 			assert.Equal(t, tt.want, got)
 		})
 	}
 }
+
+func TestTable_FindColumnIgnoreCase(t *testing.T) {
+	table := &Table{
+		Name: "users",
+		Columns: []*Column{
+			{Name: "id", Type: "integer"},
+			{Name: "Email", Type: "text"},
+		},
+	}
+
+	assert.Equal(t, table.Columns[1], table.FindColumnIgnoreCase("email"))
+	assert.Nil(t, table.FindColumnIgnoreCase("name"))
+}
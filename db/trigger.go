@@ -0,0 +1,63 @@
+package db
+
+import (
+	"github.com/deckarep/golang-set/v2"
+	"regexp"
+	"strings"
+)
+
+// Trigger represents trigger definition
+type Trigger struct {
+	Name string
+
+	// Event represents triggering event (e.g. INSERT, UPDATE, DELETE)
+	Event string
+
+	// Timing represents trigger timing (e.g. BEFORE, AFTER, INSTEAD OF)
+	Timing string
+
+	// Function represents name of function which is executed by trigger (PostgreSQL only)
+	Function string
+
+	// Body represents trigger body (or function body)
+	Body string
+}
+
+var (
+	// triggerCommentRe matches comments and string literals. String literals are matched to skip comment markers and words in them
+	triggerCommentRe = regexp.MustCompile(`(?s)'(?:[^']|'')*'|--[^\n]*|/\*.*?\*/`)
+
+	// triggerHeaderRe matches header of CREATE TRIGGER statement (SQLite) which contains name of table owning trigger
+	triggerHeaderRe = regexp.MustCompile(`(?is)^\s*CREATE\s.*?\bTRIGGER\b.*?\bBEGIN\b`)
+
+	triggerIdentifierRe = regexp.MustCompile(`[\p{L}\p{N}_$]+`)
+)
+
+// ReferencedTables returns table names which are referenced in trigger body
+func (t *Trigger) ReferencedTables(tableNames []string) []string {
+	body := triggerCommentRe.ReplaceAllStringFunc(t.Body, func(matched string) string {
+		if strings.HasPrefix(matched, "'") {
+			// Blank contents of string literal (e.g. 'order placed' -> '')
+			return "''"
+		}
+		return " "
+	})
+	body = triggerHeaderRe.ReplaceAllString(body, "")
+
+	identifiers := mapset.NewSet[string]()
+	for _, identifier := range triggerIdentifierRe.FindAllString(body, -1) {
+		identifiers.Add(strings.ToLower(identifier))
+	}
+
+	var referencedTables []string
+	for _, tableName := range tableNames {
+		// Compare without schema name (e.g. public.users -> users)
+		name := tableName[strings.LastIndex(tableName, ".")+1:]
+
+		if identifiers.Contains(strings.ToLower(name)) {
+			referencedTables = append(referencedTables, tableName)
+		}
+	}
+
+	return referencedTables
+}
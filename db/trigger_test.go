@@ -0,0 +1,77 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrigger_ReferencedTables(t *testing.T) {
+	tests := []struct {
+		name       string
+		trigger    *Trigger
+		tableNames []string
+		want       []string
+	}{
+		{
+			name: "references another table",
+			trigger: &Trigger{
+				Name: "users_audit",
+				Body: "BEGIN INSERT INTO audit_logs (user_id) VALUES (NEW.id); END",
+			},
+			tableNames: []string{"audit_logs", "logs", "users"},
+			want:       []string{"audit_logs"},
+		},
+		{
+			name: "references table with schema",
+			trigger: &Trigger{
+				Name: "users_audit",
+				Body: "BEGIN\n  INSERT INTO public.AUDIT_LOGS (user_id) VALUES (NEW.id);\n  RETURN NEW;\nEND;",
+			},
+			tableNames: []string{"public.audit_logs", "public.users"},
+			want:       []string{"public.audit_logs"},
+		},
+		{
+			name: "references in comments",
+			trigger: &Trigger{
+				Name: "users_audit",
+				Body: "BEGIN\n  -- copy to logs\n  /* audit_logs is\n legacy */\n  INSERT INTO histories (note) VALUES ('-- not a comment');\nEND;",
+			},
+			tableNames: []string{"audit_logs", "histories", "logs"},
+			want:       []string{"histories"},
+		},
+		{
+			name: "references in string literals",
+			trigger: &Trigger{
+				Name: "orders_audit",
+				Body: "BEGIN\n  INSERT INTO audit_logs (message) VALUES ('order placed in ''placed'' table');\nEND;",
+			},
+			tableNames: []string{"audit_logs", "order", "placed"},
+			want:       []string{"audit_logs"},
+		},
+		{
+			name: "sqlite trigger with header",
+			trigger: &Trigger{
+				Name: "users_audit",
+				Body: "CREATE TRIGGER users_audit AFTER UPDATE ON users FOR EACH ROW BEGIN INSERT INTO audit_logs (user_id) VALUES (NEW.id); END",
+			},
+			tableNames: []string{"audit_logs", "users"},
+			want:       []string{"audit_logs"},
+		},
+		{
+			name: "no references",
+			trigger: &Trigger{
+				Name: "users_updated_at",
+				Body: "BEGIN NEW.updated_at = now(); RETURN NEW; END;",
+			},
+			tableNames: []string{"audit_logs"},
+			want:       nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.trigger.ReferencedTables(tt.tableNames)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
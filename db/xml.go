@@ -0,0 +1,19 @@
+package db
+
+import (
+	"strings"
+)
+
+var xmlReplacer = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	`"`, "&quot;",
+	"'", "&#39;",
+	"\n", "&#xa;",
+)
+
+// xmlEscape escapes str for XML text and attribute
+func xmlEscape(str string) string {
+	return xmlReplacer.Replace(str)
+}
@@ -0,0 +1,253 @@
+(function () {
+  "use strict";
+
+  var schema = JSON.parse(document.getElementById("schema-data").textContent);
+  var tables = {};
+  schema.tables.forEach(function (table) {
+    tables[table.name] = table;
+  });
+
+  var search = document.getElementById("search");
+  var tableList = document.getElementById("table-list");
+  var distanceInput = document.getElementById("distance");
+  var distanceValue = document.getElementById("distance-value");
+  var graph = document.getElementById("graph");
+
+  var selected = null;
+
+  function element(tag, text) {
+    var el = document.createElement(tag);
+    if (text !== undefined) {
+      el.textContent = text;
+    }
+    return el;
+  }
+
+  function tableLink(name) {
+    var a = element("a", name);
+    a.addEventListener("click", function () {
+      select(name);
+    });
+    return a;
+  }
+
+  function row(tbody, cells) {
+    var tr = element("tr");
+    cells.forEach(function (cell) {
+      var td = element("td");
+      if (cell instanceof Node) {
+        td.appendChild(cell);
+      } else {
+        td.textContent = cell;
+      }
+      tr.appendChild(td);
+    });
+    tbody.appendChild(tr);
+  }
+
+  function clearRows(id) {
+    var tbody = document.querySelector("#" + id + " tbody");
+    tbody.textContent = "";
+    return tbody;
+  }
+
+  function renderList() {
+    var query = search.value.toLowerCase();
+    tableList.textContent = "";
+
+    schema.tables.forEach(function (table) {
+      if (query && table.name.toLowerCase().indexOf(query) < 0) {
+        return;
+      }
+
+      var li = element("li", table.name);
+      if (table.name === selected) {
+        li.className = "selected";
+      }
+      li.addEventListener("click", function () {
+        select(table.name);
+      });
+      tableList.appendChild(li);
+    });
+  }
+
+  function renderDetail(table) {
+    document.getElementById("empty").hidden = true;
+    document.getElementById("detail").hidden = false;
+    document.getElementById("table-name").textContent = table.name;
+    document.getElementById("table-stats").textContent = table.stats || "";
+
+    var foreignKeyColumns = {};
+    (table.foreignKeys || []).forEach(function (foreignKey) {
+      foreignKeyColumns[foreignKey.fromColumn] = true;
+    });
+
+    var tbody = clearRows("columns");
+    (table.columns || []).forEach(function (column) {
+      var keys = [];
+      if (column.primaryKey) {
+        keys.push("PK");
+      }
+      if (foreignKeyColumns[column.name]) {
+        keys.push("FK");
+      }
+      row(tbody, [column.name, column.type, keys.join(", "), column.notNull ? "yes" : "", column.default || ""]);
+    });
+
+    tbody = clearRows("indexes");
+    (table.indexes || []).forEach(function (index) {
+      row(tbody, [index.name, index.definition, index.unique ? "yes" : ""]);
+    });
+
+    tbody = clearRows("foreign-keys");
+    (table.foreignKeys || []).forEach(function (foreignKey) {
+      var references = element("span");
+      references.appendChild(tables[foreignKey.toTable] ? tableLink(foreignKey.toTable) : element("span", foreignKey.toTable));
+      references.appendChild(document.createTextNode("." + foreignKey.toColumn + (foreignKey.inferred ? " (inferred)" : "")));
+      row(tbody, [foreignKey.fromColumn, references]);
+    });
+
+    tbody = clearRows("referenced-by");
+    schema.tables.forEach(function (other) {
+      (other.foreignKeys || []).forEach(function (foreignKey) {
+        if (foreignKey.toTable === table.name) {
+          row(tbody, [tableLink(other.name), foreignKey.fromColumn, foreignKey.toColumn]);
+        }
+      });
+    });
+  }
+
+  function svgElement(tag, attributes) {
+    var el = document.createElementNS("http://www.w3.org/2000/svg", tag);
+    Object.keys(attributes || {}).forEach(function (key) {
+      el.setAttribute(key, attributes[key]);
+    });
+    return el;
+  }
+
+  // Place tables on concentric rings by distance from selected table
+  function renderGraph(table) {
+    // Distances are precomputed with SchemaExplorer
+    var distances = table.distances;
+    var maxDistance = 1;
+    Object.keys(distances).forEach(function (name) {
+      maxDistance = Math.max(maxDistance, distances[name]);
+    });
+
+    distanceInput.max = maxDistance;
+    if (Number(distanceInput.value) > maxDistance) {
+      distanceInput.value = maxDistance;
+    }
+
+    var distance = Number(distanceInput.value);
+    distanceValue.textContent = distance + " / " + maxDistance;
+
+    // Referenced tables may be excluded from schema (e.g. --skip-table)
+    var names = Object.keys(distances).filter(function (name) {
+      return distances[name] <= distance && tables[name];
+    }).sort();
+    var included = {};
+    names.forEach(function (name) {
+      included[name] = true;
+    });
+
+    var edges = [];
+    names.forEach(function (name) {
+      (tables[name].foreignKeys || []).forEach(function (foreignKey) {
+        if (included[foreignKey.toTable]) {
+          edges.push([name, foreignKey.toTable, foreignKey.inferred]);
+        }
+      });
+    });
+
+    var rings = [];
+    names.forEach(function (name) {
+      var depth = distances[name];
+      rings[depth] = rings[depth] || [];
+      rings[depth].push(name);
+    });
+
+    var width = graph.clientWidth || 800;
+    var height = graph.clientHeight || 420;
+    var ringCount = rings.length;
+    var ringGap = Math.max(Math.min(width, height) / 2 / Math.max(ringCount, 1), 90);
+
+    var positions = {};
+    rings.forEach(function (ring, depth) {
+      (ring || []).forEach(function (name, i) {
+        var angle = (2 * Math.PI * i) / ring.length - Math.PI / 2 + depth * 0.3;
+        positions[name] = {
+          x: width / 2 + Math.cos(angle) * ringGap * depth,
+          y: height / 2 + Math.sin(angle) * ringGap * depth * 0.8,
+        };
+      });
+    });
+
+    var minX = 0, minY = 0, maxX = width, maxY = height;
+    Object.keys(positions).forEach(function (name) {
+      minX = Math.min(minX, positions[name].x - 80);
+      minY = Math.min(minY, positions[name].y - 20);
+      maxX = Math.max(maxX, positions[name].x + 80);
+      maxY = Math.max(maxY, positions[name].y + 20);
+    });
+
+    graph.textContent = "";
+    graph.setAttribute("viewBox", [minX, minY, maxX - minX, maxY - minY].join(" "));
+
+    edges.forEach(function (edge) {
+      var from = positions[edge[0]];
+      var to = positions[edge[1]];
+      graph.appendChild(svgElement("line", {"class": edge[2] ? "edge inferred" : "edge", x1: from.x, y1: from.y, x2: to.x, y2: to.y}));
+    });
+
+    names.forEach(function (name) {
+      var position = positions[name];
+      var boxWidth = Math.max(name.length * 7 + 16, 48);
+
+      var group = svgElement("g", {"class": name === table.name ? "node selected" : "node"});
+      group.appendChild(svgElement("rect", {x: position.x - boxWidth / 2, y: position.y - 12, width: boxWidth, height: 24}));
+
+      var text = svgElement("text", {x: position.x, y: position.y});
+      text.textContent = name;
+      group.appendChild(text);
+
+      group.addEventListener("click", function () {
+        select(name);
+      });
+      graph.appendChild(group);
+    });
+  }
+
+  function select(name) {
+    var table = tables[name];
+    if (!table) {
+      return;
+    }
+
+    selected = name;
+    if (decodeURIComponent(location.hash.slice(1)) !== name) {
+      location.hash = encodeURIComponent(name);
+    }
+
+    renderList();
+    renderDetail(table);
+    renderGraph(table);
+  }
+
+  search.addEventListener("input", renderList);
+
+  distanceInput.addEventListener("input", function () {
+    if (selected) {
+      renderGraph(tables[selected]);
+    }
+  });
+
+  window.addEventListener("hashchange", function () {
+    select(decodeURIComponent(location.hash.slice(1)));
+  });
+
+  renderList();
+  if (location.hash.length > 1) {
+    select(decodeURIComponent(location.hash.slice(1)));
+  }
+})();
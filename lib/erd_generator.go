@@ -5,18 +5,84 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/sue445/plant_erd/db"
 	"os"
+	"path/filepath"
 	"regexp"
+	"sort"
+	"strings"
 )
 
+// ClusterIndexName represents file name (without extension) of index diagram of clusters
+const ClusterIndexName = "index"
+
 // ErdGenerator represents ERD generator
 type ErdGenerator struct {
-	Filepath    string
-	Table       string
-	Distance    int
-	SKipIndex   bool
-	SkipTable   string
-	Format      string
-	ShowComment bool
+	Filepath       string
+	Table          string
+	Distance       int
+	SKipIndex      bool
+	SkipTable      string
+	Format         string
+	ShowComment    bool
+	ShowPartitions bool
+	ShowTrigger    bool
+	MinRows        int
+	SortBy         string
+	EmbedMermaid   bool
+
+	// Style represents diagram style of plant_uml and mermaid format (er, class)
+	Style string
+
+	// Template represents path of text/template file which is used instead of Format
+	Template string
+
+	// InferForeignKeys represents whether foreign keys are inferred from naming convention of columns (e.g. user_id -> users.id)
+	InferForeignKeys bool
+
+	// ForeignKeyPatterns represents patterns of inferred foreign keys (e.g. {table}_id=id). db.DefaultForeignKeyPattern is used when this is empty
+	ForeignKeyPatterns []string
+
+	// DetectPolymorphic represents whether polymorphic associations are detected from <name>_type and <name>_id columns
+	DetectPolymorphic bool
+
+	// Columns represents which columns are printed to plant_uml and mermaid format (all, keys, none)
+	Columns string
+
+	// SkipColumns represents regex patterns of columns which aren't printed to any format
+	SkipColumns []string
+
+	// GroupBy represents how tables are grouped (schema, prefix)
+	GroupBy string
+
+	// GroupConfig represents path of JSON file which maps tables to groups. This is used instead of GroupBy
+	GroupConfig string
+
+	// GroupStyle represents PlantUML block which wraps tables of the same group (package, namespace, rectangle)
+	GroupStyle string
+
+	// TagConfig represents path of JSON file which adds stereotypes and colors to tables (plant_uml only)
+	TagConfig string
+
+	// Decoration represents theme, skinparam, title, header, footer and legend (plant_uml only)
+	Decoration db.PlantUmlDecoration
+
+	// RelationLabel represents label of relation (column, name)
+	RelationLabel string
+
+	// RelationColumn represents whether relation connects columns instead of tables (plant_uml only)
+	RelationColumn bool
+
+	// SplitDir represents directory which one file per table (markdown format only) or one file per cluster (with Split) is written into
+	SplitDir string
+
+	// Split represents how schema is split into clusters (component, cluster)
+	Split string
+
+	// From and To represent tables which are both ends of paths. Only tables and relations on shortest paths between them are printed
+	From string
+	To   string
+
+	// Paths represents number of paths between From and To. All shortest paths are printed when this is 0
+	Paths int
 }
 
 // NewErdGenerator returns a new NewErdGenerator instance
@@ -33,6 +99,14 @@ func (g *ErdGenerator) Run(schema *db.Schema) error {
 		return errors.WithStack(err)
 	}
 
+	if g.Split != "" {
+		return g.outputClusters(schema)
+	}
+
+	if g.SplitDir != "" {
+		return g.outputSplitFiles(schema)
+	}
+
 	erd, err := g.generate(schema)
 	if err != nil {
 		return errors.WithStack(err)
@@ -42,61 +116,352 @@ func (g *ErdGenerator) Run(schema *db.Schema) error {
 }
 
 func (g *ErdGenerator) checkParamTable(schema *db.Schema) error {
-	if g.Table == "" {
-		return nil
-	}
+	for _, tableName := range []string{g.Table, g.From, g.To} {
+		if tableName == "" {
+			continue
+		}
 
-	for _, table := range schema.Tables {
-		if table.Name == g.Table {
-			return nil
+		found := false
+		for _, table := range schema.Tables {
+			if table.Name == tableName {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("%s is not found in database", tableName)
 		}
 	}
 
-	return fmt.Errorf("%s is not found in database", g.Table)
+	return nil
 }
 
 func (g *ErdGenerator) generate(schema *db.Schema) (string, error) {
+	schema, renderer, err := g.prepareRender(schema)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	erd, err := renderer.Render(schema, g.renderOption())
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return erd, nil
+}
+
+// prepareRender returns schema which is filtered with options and renderer of format
+func (g *ErdGenerator) prepareRender(schema *db.Schema) (*db.Schema, Renderer, error) {
+	table := g.paramTableName(schema, g.Table)
+	from := g.paramTableName(schema, g.From)
+	to := g.paramTableName(schema, g.To)
+
+	schema, err := g.prepareSchema(schema)
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	switch g.Style {
+	case "", "er", "class":
+	default:
+		return nil, nil, fmt.Errorf("%s is unknown style", g.Style)
+	}
+
+	switch g.Columns {
+	case "", "all", "keys", "none":
+	default:
+		return nil, nil, fmt.Errorf("%s is unknown columns", g.Columns)
+	}
+
+	switch g.RelationLabel {
+	case "", "column", "name", "join":
+	default:
+		return nil, nil, fmt.Errorf("%s is unknown relation label", g.RelationLabel)
+	}
+
+	switch g.GroupStyle {
+	case "", "package", "namespace", "rectangle":
+	default:
+		return nil, nil, fmt.Errorf("%s is unknown group style", g.GroupStyle)
+	}
+
+	renderer, err := g.renderer()
+	if err != nil {
+		return nil, nil, errors.WithStack(err)
+	}
+
+	if table != "" && g.Distance > 0 {
+		schema = schema.Subset(table, g.Distance)
+	}
+
+	if from != "" || to != "" {
+		schema, err = g.pathSchema(schema, from, to)
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+	}
+
+	// Tables are sorted after subsetting, because Subset sorts tables by name
+	if g.SortBy != "" {
+		schema, err = g.sortSchema(schema)
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+	}
+
+	return schema, renderer, nil
+}
+
+// paramTableName returns name of table which tableName is merged into when partitions are collapsed (e.g. events_2024_01 -> events)
+func (g *ErdGenerator) paramTableName(schema *db.Schema, tableName string) string {
+	if tableName == "" || g.ShowPartitions {
+		return tableName
+	}
+	return schema.PartitionRoot(tableName)
+}
+
+// pathSchema returns schema which has only tables and relations on shortest paths between from and to
+func (g *ErdGenerator) pathSchema(schema *db.Schema, from string, to string) (*db.Schema, error) {
+	if from == "" || to == "" {
+		return nil, fmt.Errorf("--from and --to must be specified together")
+	}
+
+	if g.Table != "" {
+		return nil, fmt.Errorf("--table and --from cannot be used together")
+	}
+
+	paths := db.NewSchemaExplorer(schema).ShortestPaths(from, to, g.Paths)
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("path from %s to %s is not found", from, to)
+	}
+
+	return schema.PathSubset(paths), nil
+}
+
+func (g *ErdGenerator) renderer() (Renderer, error) {
+	if g.Template == "" {
+		return FindRenderer(g.Format)
+	}
+
+	if g.Format != "" {
+		return nil, fmt.Errorf("--format and --template cannot be used together")
+	}
+
+	return NewTemplateRenderer(g.Template)
+}
+
+func (g *ErdGenerator) renderOption() *RenderOption {
+	relationLabel := g.RelationLabel
+	if relationLabel == "" && g.From != "" {
+		// Paths are labelled with join columns by default
+		relationLabel = "join"
+	}
+
+	return &RenderOption{
+		ShowIndex:    !g.SKipIndex,
+		ShowComment:  g.ShowComment,
+		ShowTrigger:  g.ShowTrigger,
+		EmbedMermaid: g.EmbedMermaid,
+		Style:        g.Style,
+		Columns:      g.Columns,
+		GroupStyle:   g.GroupStyle,
+		Decoration:   g.Decoration,
+		Relation: db.RelationOption{
+			Label:      relationLabel,
+			LinkColumn: g.RelationColumn,
+		},
+	}
+}
+
+// prepareSchema returns schema which is filtered and sorted with options
+func (g *ErdGenerator) prepareSchema(schema *db.Schema) (*db.Schema, error) {
+	if !g.ShowPartitions {
+		schema = schema.CollapsePartitions()
+	}
+
 	if g.SkipTable != "" {
 		schema = g.filterSchema(schema, []string{g.SkipTable})
 	}
 
-	switch g.Format {
-	case "", "plant_uml":
-		return g.generatePlantUmlErd(schema), nil
-	case "mermaid":
-		return g.generateMermaidErd(schema), nil
+	if g.MinRows > 0 {
+		schema = g.filterSchemaByRows(schema)
+	}
+
+	if g.InferForeignKeys {
+		inferred, err := schema.InferForeignKeys(g.ForeignKeyPatterns)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		schema = inferred
+	}
+
+	if g.DetectPolymorphic {
+		schema = schema.DetectPolymorphicAssociations()
+	}
+
+	// Tables are tagged before columns are skipped, because rules refer all columns (e.g. noPrimaryKey)
+	if g.TagConfig != "" {
+		config, err := LoadTagConfig(g.TagConfig)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		schema = db.NewSchema(config.tagTables(schema.Tables))
+	}
+
+	if len(g.SkipColumns) > 0 {
+		filtered, err := g.filterColumns(schema)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		schema = filtered
+	}
+
+	if g.GroupBy != "" || g.GroupConfig != "" {
+		grouped, err := g.groupSchema(schema)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		schema = grouped
 	}
 
-	return "", fmt.Errorf("%s is unknown format", g.Format)
+	return schema, nil
 }
 
-func (g *ErdGenerator) generatePlantUmlErd(schema *db.Schema) string {
-	if g.Table == "" || g.Distance <= 0 {
-		return schema.ToErd(!g.SKipIndex)
+// groupSchema returns schema which Group of tables is set with GroupBy or GroupConfig
+func (g *ErdGenerator) groupSchema(schema *db.Schema) (*db.Schema, error) {
+	if g.GroupConfig != "" {
+		if g.GroupBy != "" {
+			return nil, fmt.Errorf("--group-by and --group-config cannot be used together")
+		}
+
+		config, err := LoadGroupConfig(g.GroupConfig)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		return db.NewSchema(groupTables(schema.Tables, config.groupName, false)), nil
 	}
 
-	subset := schema.Subset(g.Table, g.Distance)
-	return subset.ToErd(!g.SKipIndex)
+	switch g.GroupBy {
+	case "schema":
+		return db.NewSchema(groupTables(schema.Tables, schemaGroupName, false)), nil
+	case "prefix":
+		return db.NewSchema(groupTables(schema.Tables, prefixGroupName, true)), nil
+	}
+
+	return nil, fmt.Errorf("%s is unknown group key", g.GroupBy)
 }
 
-func (g *ErdGenerator) generateMermaidErd(schema *db.Schema) string {
-	if g.Table == "" || g.Distance <= 0 {
-		return schema.ToMermaid(g.ShowComment)
+// outputSplitFiles writes one markdown file per table into SplitDir
+func (g *ErdGenerator) outputSplitFiles(schema *db.Schema) error {
+	if g.Format != "markdown" {
+		return fmt.Errorf("--split-dir is available only with markdown format or --split")
+	}
+
+	table := g.paramTableName(schema, g.Table)
+
+	schema, err := g.prepareSchema(schema)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if table != "" && g.Distance > 0 {
+		schema = schema.Subset(table, g.Distance)
+	}
+
+	// Tables are sorted after subsetting, because Subset sorts tables by name
+	if g.SortBy != "" {
+		schema, err = g.sortSchema(schema)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	err = os.MkdirAll(g.SplitDir, 0755)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for filename, content := range schema.ToMarkdownFiles(g.EmbedMermaid) {
+		err := os.WriteFile(filepath.Join(g.SplitDir, filename), []byte(content), 0644)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	return nil
+}
+
+// outputClusters writes one diagram per cluster and index diagram of clusters into SplitDir
+func (g *ErdGenerator) outputClusters(schema *db.Schema) error {
+	if g.SplitDir == "" {
+		return fmt.Errorf("--split requires --split-dir")
+	}
+
+	schema, renderer, err := g.prepareRender(schema)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	var clusters []*db.Cluster
+	switch g.Split {
+	case "component":
+		clusters = schema.SplitByComponent()
+	case "cluster":
+		clusters = schema.SplitByCommunity()
+	default:
+		return fmt.Errorf("%s is unknown split mode", g.Split)
+	}
+
+	// Cluster may have the same name as index diagram
+	clusters = db.UniqueClusterNames(clusters, ClusterIndexName)
+
+	err = os.MkdirAll(g.SplitDir, 0755)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	extension := RendererExtension(g.Format)
+	if g.Template != "" {
+		extension = ".txt"
+	}
+
+	contents := map[string]*db.Schema{ClusterIndexName: schema.ClusterIndex(clusters)}
+	for _, cluster := range clusters {
+		contents[cluster.Name] = db.NewSchema(cluster.Tables)
+	}
+
+	for name, cluster := range contents {
+		content, err := renderer.Render(cluster, g.renderOption())
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		filename := strings.ReplaceAll(name, "/", "_") + extension
+		err = os.WriteFile(filepath.Join(g.SplitDir, filename), []byte(content), 0644)
+		if err != nil {
+			return errors.WithStack(err)
+		}
 	}
 
-	subset := schema.Subset(g.Table, g.Distance)
-	return subset.ToMermaid(g.ShowComment)
+	return nil
 }
 
 func (g *ErdGenerator) output(content string) error {
-	if g.Filepath == "" {
+	return writeOutput(g.Filepath, content)
+}
+
+// writeOutput writes content to file. content is printed to stdout when filepath is empty
+func writeOutput(filepath string, content string) error {
+	if filepath == "" {
 		// Print to stdout
 		fmt.Fprint(os.Stdout, content)
 		return nil
 	}
 
 	// Output to file
-	err := os.WriteFile(g.Filepath, []byte(content), 0644)
+	err := os.WriteFile(filepath, []byte(content), 0644)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -115,6 +480,86 @@ func (g *ErdGenerator) filterSchema(schema *db.Schema, skipTable []string) *db.S
 	return db.NewSchema(tables)
 }
 
+func (g *ErdGenerator) filterSchemaByRows(schema *db.Schema) *db.Schema {
+	var tables []*db.Table
+	for _, table := range schema.Tables {
+		// Keep table when stats isn't loaded
+		if table.Stats != nil && table.Stats.Rows < int64(g.MinRows) {
+			continue
+		}
+		tables = append(tables, table)
+	}
+	return db.NewSchema(tables)
+}
+
+func (g *ErdGenerator) sortSchema(schema *db.Schema) (*db.Schema, error) {
+	tables := make([]*db.Table, len(schema.Tables))
+	copy(tables, schema.Tables)
+
+	statsValue := func(table *db.Table) int64 {
+		if table.Stats == nil {
+			return -1
+		}
+		if g.SortBy == "size" {
+			return table.Stats.Size
+		}
+		return table.Stats.Rows
+	}
+
+	switch g.SortBy {
+	case "name":
+		sort.SliceStable(tables, func(i, j int) bool {
+			return tables[i].Name < tables[j].Name
+		})
+	case "rows", "size":
+		// Larger tables first
+		sort.SliceStable(tables, func(i, j int) bool {
+			return statsValue(tables[i]) > statsValue(tables[j])
+		})
+	default:
+		return nil, fmt.Errorf("%s is unknown sort key", g.SortBy)
+	}
+
+	return db.NewSchema(tables), nil
+}
+
+// filterColumns returns schema which columns matched with SkipColumns are removed from
+func (g *ErdGenerator) filterColumns(schema *db.Schema) (*db.Schema, error) {
+	var patterns []*regexp.Regexp
+	for _, pattern := range g.SkipColumns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		patterns = append(patterns, re)
+	}
+
+	var tables []*db.Table
+	for _, table := range schema.Tables {
+		var columns []*db.Column
+		for _, column := range table.Columns {
+			if !matchedAny(patterns, column.Name) {
+				columns = append(columns, column)
+			}
+		}
+
+		copied := *table
+		copied.Columns = columns
+		tables = append(tables, &copied)
+	}
+
+	return db.NewSchema(tables), nil
+}
+
+func matchedAny(patterns []*regexp.Regexp, str string) bool {
+	for _, re := range patterns {
+		if re.MatchString(str) {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *ErdGenerator) matchedSkippedTable(skipPatterns []string, tableName string) bool {
 	for _, pattern := range skipPatterns {
 		if matched, _ := regexp.MatchString(pattern, tableName); matched {
@@ -13,6 +13,14 @@ import (
 	"github.com/sue445/plant_erd/db"
 )
 
+// writeTempFile writes content to file named name in temporary directory and returns its path
+func writeTempFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(path, []byte(content), 0644)
+	require.NoError(t, err)
+	return path
+}
+
 func withDatabase(callback func(*sqlite3.Adapter)) {
 	adapter, closeDatabase, err := sqlite3.NewAdapter("file::memory:?cache=shared")
 
@@ -27,7 +35,7 @@ func withDatabase(callback func(*sqlite3.Adapter)) {
 	callback(adapter)
 }
 
-func TestErdGenerator_generatePlantUmlErd(t *testing.T) {
+func TestErdGenerator_generate_PlantUml(t *testing.T) {
 	tables := []*db.Table{
 		{
 			Name: "articles",
@@ -110,14 +118,17 @@ func TestErdGenerator_generatePlantUmlErd(t *testing.T) {
 				Filepath: tt.fields.Filepath,
 				Table:    tt.fields.Table,
 				Distance: tt.fields.Distance,
+				Format:   "plant_uml",
+			}
+			got, err := g.generate(tt.args.schema)
+			if assert.NoError(t, err) {
+				assert.NotEmpty(t, got)
 			}
-			got := g.generatePlantUmlErd(tt.args.schema)
-			assert.NotEmpty(t, got)
 		})
 	}
 }
 
-func TestErdGenerator_generateMermaidErd(t *testing.T) {
+func TestErdGenerator_generate_Mermaid(t *testing.T) {
 	tables := []*db.Table{
 		{
 			Name: "articles",
@@ -200,9 +211,12 @@ func TestErdGenerator_generateMermaidErd(t *testing.T) {
 				Filepath: tt.fields.Filepath,
 				Table:    tt.fields.Table,
 				Distance: tt.fields.Distance,
+				Format:   "mermaid",
+			}
+			got, err := g.generate(tt.args.schema)
+			if assert.NoError(t, err) {
+				assert.NotEmpty(t, got)
 			}
-			got := g.generateMermaidErd(tt.args.schema)
-			assert.NotEmpty(t, got)
 		})
 	}
 }
@@ -266,6 +280,145 @@ func TestErdGenerator_output_ToStdout(t *testing.T) {
 	assert.Equal(t, "aaa", str)
 }
 
+func TestErdGenerator_outputSplitFiles(t *testing.T) {
+	schema := db.NewSchema([]*db.Table{
+		{
+			Name:    "articles",
+			Columns: []*db.Column{{Name: "user_id", Type: "integer"}},
+			ForeignKeys: []*db.ForeignKey{
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+			},
+		},
+		{
+			Name:    "users",
+			Columns: []*db.Column{{Name: "id", Type: "integer", PrimaryKey: true}},
+		},
+	})
+
+	t.Run("markdown", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "docs")
+		g := &ErdGenerator{Format: "markdown", SplitDir: dir}
+
+		err := g.Run(schema)
+		require.NoError(t, err)
+
+		files, err := filepath.Glob(filepath.Join(dir, "*.md"))
+		if assert.NoError(t, err) {
+			assert.ElementsMatch(t, []string{
+				filepath.Join(dir, "README.md"),
+				filepath.Join(dir, "articles.md"),
+				filepath.Join(dir, "users.md"),
+			}, files)
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, "articles.md"))
+		if assert.NoError(t, err) {
+			assert.Contains(t, string(data), "* `user_id` → [users](users.md) (`id`)")
+		}
+	})
+
+	t.Run("other format", func(t *testing.T) {
+		g := &ErdGenerator{Format: "mermaid", SplitDir: t.TempDir()}
+
+		err := g.Run(schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--split-dir is available only with markdown format or --split")
+	})
+}
+
+func TestErdGenerator_outputClusters(t *testing.T) {
+	schema := db.NewSchema([]*db.Table{
+		{
+			Name:    "articles",
+			Columns: []*db.Column{{Name: "user_id", Type: "integer"}},
+			ForeignKeys: []*db.ForeignKey{
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+			},
+		},
+		{
+			Name:    "users",
+			Columns: []*db.Column{{Name: "id", Type: "integer", PrimaryKey: true}},
+		},
+		{
+			Name:    "settings",
+			Columns: []*db.Column{{Name: "id", Type: "integer", PrimaryKey: true}},
+		},
+	})
+
+	t.Run("component", func(t *testing.T) {
+		dir := t.TempDir()
+		g := &ErdGenerator{Format: "mermaid", Split: "component", SplitDir: dir}
+
+		err := g.Run(schema)
+		require.NoError(t, err)
+
+		files, err := filepath.Glob(filepath.Join(dir, "*"))
+		if assert.NoError(t, err) {
+			assert.ElementsMatch(t, []string{
+				filepath.Join(dir, "index.mmd"),
+				filepath.Join(dir, "articles.mmd"),
+				filepath.Join(dir, "isolated.mmd"),
+			}, files)
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, "articles.mmd"))
+		if assert.NoError(t, err) {
+			assert.Contains(t, string(data), "users ||--o{ articles : owns")
+			assert.NotContains(t, string(data), "settings")
+		}
+
+		data, err = os.ReadFile(filepath.Join(dir, "index.mmd"))
+		if assert.NoError(t, err) {
+			assert.Contains(t, string(data), "articles {\n  table articles\n  table users\n}")
+			assert.Contains(t, string(data), "isolated {\n  table settings\n}")
+		}
+	})
+
+	t.Run("cluster which has the same name as index", func(t *testing.T) {
+		dir := t.TempDir()
+		g := &ErdGenerator{Format: "mermaid", Split: "component", SplitDir: dir}
+
+		err := g.Run(db.NewSchema([]*db.Table{
+			{Name: "index", Columns: []*db.Column{{Name: "id", Type: "integer", PrimaryKey: true}}},
+			{
+				Name:        "entries",
+				Columns:     []*db.Column{{Name: "index_id", Type: "integer"}},
+				ForeignKeys: []*db.ForeignKey{{FromColumn: "index_id", ToTable: "index", ToColumn: "id"}},
+			},
+		}))
+		require.NoError(t, err)
+
+		files, err := filepath.Glob(filepath.Join(dir, "*"))
+		if assert.NoError(t, err) {
+			assert.ElementsMatch(t, []string{
+				filepath.Join(dir, "index.mmd"),
+				filepath.Join(dir, "index_2.mmd"),
+			}, files)
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, "index.mmd"))
+		if assert.NoError(t, err) {
+			assert.Contains(t, string(data), "index_2 {\n  table index\n  table entries\n}")
+		}
+	})
+
+	t.Run("without --split-dir", func(t *testing.T) {
+		g := &ErdGenerator{Split: "component"}
+
+		err := g.Run(schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--split requires --split-dir")
+	})
+
+	t.Run("unknown mode", func(t *testing.T) {
+		g := &ErdGenerator{Split: "unknown", SplitDir: t.TempDir()}
+
+		err := g.Run(schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown is unknown split mode")
+	})
+}
+
 func TestErdGenerator_generate_withSkipTable(t *testing.T) {
 	tables := []*db.Table{
 		{
@@ -351,11 +504,448 @@ func TestErdGenerator_generate_withSkipTable(t *testing.T) {
 	}
 }
 
+func TestErdGenerator_generate_withStyle(t *testing.T) {
+	schema := db.NewSchema([]*db.Table{
+		{
+			Name:    "users",
+			Columns: []*db.Column{{Name: "name", Type: "varchar(255)"}},
+		},
+	})
+
+	tests := []struct {
+		name   string
+		format string
+		style  string
+		want   string
+	}{
+		{
+			name:   "plant_uml and class",
+			format: "plant_uml",
+			style:  "class",
+			want:   "hide empty methods\n\nclass users {\n  {field} name : varchar(255)\n}",
+		},
+		{
+			name:   "mermaid and class",
+			format: "mermaid",
+			style:  "class",
+			want:   "classDiagram\n\nclass users {\n  varchar(255) name\n}",
+		},
+		{
+			name:   "mermaid and er",
+			format: "mermaid",
+			style:  "er",
+			want:   "erDiagram\n\nusers {\n  varchar_255 name\n}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &ErdGenerator{Format: tt.format, Style: tt.style}
+			got, err := g.generate(schema)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+
+	t.Run("unknown style", func(t *testing.T) {
+		g := &ErdGenerator{Format: "mermaid", Style: "unknown"}
+		_, err := g.generate(schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown is unknown style")
+	})
+}
+
+func TestErdGenerator_generate_withColumns(t *testing.T) {
+	schema := db.NewSchema([]*db.Table{
+		{
+			Name: "articles",
+			Columns: []*db.Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+				{Name: "user_id", Type: "integer", NotNull: true},
+				{Name: "title", Type: "text"},
+				{Name: "created_at", Type: "datetime"},
+				{Name: "updated_at", Type: "datetime"},
+			},
+			ForeignKeys: []*db.ForeignKey{
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+			},
+		},
+	})
+
+	tests := []struct {
+		name        string
+		columns     string
+		skipColumns []string
+		want        string
+	}{
+		{
+			name:        "with skip columns",
+			skipColumns: []string{"created_at|updated_at", "^title$"},
+			want:        "entity articles {\n  * id : integer\n  --\n  * user_id : integer\n}",
+		},
+		{
+			name:    "only key columns",
+			columns: "keys",
+			want:    "entity articles {\n  * id : integer\n  --\n  * user_id : integer\n}",
+		},
+		{
+			name:    "without columns",
+			columns: "none",
+			want:    "entity articles {\n}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &ErdGenerator{Columns: tt.columns, SkipColumns: tt.skipColumns}
+			got, err := g.generate(schema)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+
+	t.Run("unknown columns", func(t *testing.T) {
+		g := &ErdGenerator{Columns: "unknown"}
+		_, err := g.generate(schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown is unknown columns")
+	})
+
+	t.Run("invalid skip column pattern", func(t *testing.T) {
+		g := &ErdGenerator{SkipColumns: []string{"("}}
+		_, err := g.generate(schema)
+		assert.Error(t, err)
+	})
+
+	t.Run("schema isn't modified", func(t *testing.T) {
+		g := &ErdGenerator{SkipColumns: []string{"_at$"}}
+		_, err := g.generate(schema)
+		if assert.NoError(t, err) {
+			assert.Len(t, schema.Tables[0].Columns, 5)
+		}
+	})
+}
+
+func TestErdGenerator_generate_withPath(t *testing.T) {
+	schema := db.NewSchema([]*db.Table{
+		{
+			Name: "invoices",
+			ForeignKeys: []*db.ForeignKey{
+				{FromColumn: "order_id", ToTable: "orders", ToColumn: "id"},
+			},
+		},
+		{
+			Name: "orders",
+			ForeignKeys: []*db.ForeignKey{
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+				{FromColumn: "warehouse_id", ToTable: "warehouses", ToColumn: "id"},
+			},
+		},
+		{
+			Name: "users",
+		},
+		{
+			Name: "warehouses",
+		},
+	})
+
+	t.Run("labelled with join columns by default", func(t *testing.T) {
+		g := &ErdGenerator{From: "invoices", To: "warehouses"}
+		got, err := g.generate(schema)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "entity invoices {\n}\n\nentity orders {\n}\n\nentity warehouses {\n}\n\ninvoices }-- orders : order_id = orders.id\n\norders }-- warehouses : warehouse_id = warehouses.id", got)
+		}
+	})
+
+	t.Run("with partition", func(t *testing.T) {
+		partitioned := db.NewSchema(append([]*db.Table{{Name: "invoices_2024", PartitionOf: "invoices"}}, schema.Tables...))
+
+		g := &ErdGenerator{From: "invoices_2024", To: "warehouses"}
+		got, err := g.generate(partitioned)
+		if assert.NoError(t, err) {
+			assert.Contains(t, got, "invoices }-- orders : order_id = orders.id")
+			assert.NotContains(t, got, "invoices_2024")
+		}
+	})
+
+	t.Run("with --relation-label", func(t *testing.T) {
+		g := &ErdGenerator{From: "invoices", To: "warehouses", RelationLabel: "column"}
+		got, err := g.generate(schema)
+		if assert.NoError(t, err) {
+			assert.Contains(t, got, "orders }-- warehouses : warehouse_id")
+			assert.NotContains(t, got, "users")
+		}
+	})
+
+	tests := []struct {
+		name    string
+		g       *ErdGenerator
+		wantErr string
+	}{
+		{
+			name:    "without --to",
+			g:       &ErdGenerator{From: "invoices"},
+			wantErr: "--from and --to must be specified together",
+		},
+		{
+			name:    "with --table",
+			g:       &ErdGenerator{From: "invoices", To: "warehouses", Table: "users"},
+			wantErr: "--table and --from cannot be used together",
+		},
+		{
+			name:    "path isn't found",
+			g:       &ErdGenerator{From: "invoices", To: "unknown"},
+			wantErr: "path from invoices to unknown is not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.g.generate(schema)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestErdGenerator_generate_withInferForeignKeys(t *testing.T) {
+	schema := db.NewSchema([]*db.Table{
+		{
+			Name: "articles",
+			Columns: []*db.Column{
+				{Name: "id", Type: "integer", PrimaryKey: true},
+				{Name: "author_code", Type: "varchar(10)"},
+				{Name: "user_id", Type: "integer"},
+			},
+		},
+		{
+			Name: "authors",
+			Columns: []*db.Column{
+				{Name: "code", Type: "varchar(10)", PrimaryKey: true},
+			},
+		},
+		{
+			Name: "users",
+			Columns: []*db.Column{
+				{Name: "id", Type: "integer", PrimaryKey: true},
+			},
+		},
+	})
+
+	t.Run("without --infer-foreign-keys", func(t *testing.T) {
+		g := &ErdGenerator{ForeignKeyPatterns: []string{"{table}_code=code"}}
+		got, err := g.generate(schema)
+		if assert.NoError(t, err) {
+			assert.NotContains(t, got, "}..")
+		}
+	})
+
+	t.Run("with default pattern", func(t *testing.T) {
+		g := &ErdGenerator{InferForeignKeys: true}
+		got, err := g.generate(schema)
+		if assert.NoError(t, err) {
+			assert.Contains(t, got, "articles }.. users")
+			assert.NotContains(t, got, "articles }.. authors")
+		}
+	})
+
+	t.Run("with --foreign-key-pattern", func(t *testing.T) {
+		g := &ErdGenerator{InferForeignKeys: true, ForeignKeyPatterns: []string{"{table}_code=code"}}
+		got, err := g.generate(schema)
+		if assert.NoError(t, err) {
+			assert.Contains(t, got, "articles }.. authors")
+			assert.NotContains(t, got, "articles }.. users")
+		}
+	})
+
+	t.Run("with invalid pattern", func(t *testing.T) {
+		g := &ErdGenerator{InferForeignKeys: true, ForeignKeyPatterns: []string{"user_id"}}
+		_, err := g.generate(schema)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "user_id is invalid foreign key pattern")
+		}
+	})
+}
+
+func TestErdGenerator_generate_withDetectPolymorphic(t *testing.T) {
+	schema := db.NewSchema([]*db.Table{
+		{
+			Name:    "articles",
+			Columns: []*db.Column{{Name: "id", Type: "integer", PrimaryKey: true}},
+		},
+		{
+			Name: "comments",
+			Columns: []*db.Column{
+				{Name: "commentable_type", Type: "varchar(255)", SampleValues: []string{"Article"}},
+				{Name: "commentable_id", Type: "integer"},
+			},
+		},
+	})
+
+	t.Run("without --polymorphic", func(t *testing.T) {
+		g := &ErdGenerator{}
+		got, err := g.generate(schema)
+		if assert.NoError(t, err) {
+			assert.NotContains(t, got, "comments }.. articles")
+		}
+	})
+
+	t.Run("with --polymorphic", func(t *testing.T) {
+		g := &ErdGenerator{DetectPolymorphic: true, Format: "mermaid"}
+		got, err := g.generate(schema)
+		if assert.NoError(t, err) {
+			assert.Contains(t, got, `articles ||..o{ comments : "commentable"`)
+		}
+	})
+
+	t.Run("with --polymorphic and --distance", func(t *testing.T) {
+		g := &ErdGenerator{DetectPolymorphic: true, Table: "articles", Distance: 1}
+		got, err := g.generate(schema)
+		if assert.NoError(t, err) {
+			assert.Contains(t, got, "comments }.. articles : commentable")
+		}
+	})
+}
+
+func TestErdGenerator_generate_withMinRows(t *testing.T) {
+	tables := []*db.Table{
+		{
+			Name:  "articles",
+			Stats: &db.TableStats{Rows: 1000},
+		},
+		{
+			Name:  "users",
+			Stats: &db.TableStats{Rows: 10},
+		},
+		{
+			Name: "comments",
+		},
+	}
+	schema := db.NewSchema(tables)
+
+	g := &ErdGenerator{
+		MinRows: 100,
+	}
+	got, err := g.generate(schema)
+	if assert.NoError(t, err) {
+		assert.Contains(t, got, "entity articles")
+		assert.Contains(t, got, "entity comments")
+		assert.NotContains(t, got, "entity users")
+	}
+}
+
+func TestErdGenerator_sortSchema(t *testing.T) {
+	articles := &db.Table{
+		Name:  "articles",
+		Stats: &db.TableStats{Rows: 1000, Size: 1024},
+	}
+	comments := &db.Table{
+		Name: "comments",
+	}
+	users := &db.Table{
+		Name:  "users",
+		Stats: &db.TableStats{Rows: 10, Size: 4096},
+	}
+	schema := db.NewSchema([]*db.Table{users, comments, articles})
+
+	tests := []struct {
+		name    string
+		sortBy  string
+		want    []*db.Table
+		wantErr bool
+	}{
+		{
+			name:   "sort by name",
+			sortBy: "name",
+			want:   []*db.Table{articles, comments, users},
+		},
+		{
+			name:   "sort by rows",
+			sortBy: "rows",
+			want:   []*db.Table{articles, users, comments},
+		},
+		{
+			name:   "sort by size",
+			sortBy: "size",
+			want:   []*db.Table{users, articles, comments},
+		},
+		{
+			name:    "unknown key",
+			sortBy:  "unknown",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &ErdGenerator{
+				SortBy: tt.sortBy,
+			}
+			got, err := g.sortSchema(schema)
+
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got.Tables)
+			}
+		})
+	}
+}
+
+func TestErdGenerator_generate_withSortByAndTable(t *testing.T) {
+	schema := db.NewSchema([]*db.Table{
+		{
+			Name:  "articles",
+			Stats: &db.TableStats{Rows: 10},
+			ForeignKeys: []*db.ForeignKey{
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+			},
+		},
+		{
+			Name:  "logs",
+			Stats: &db.TableStats{Rows: 100000},
+		},
+		{
+			Name:  "users",
+			Stats: &db.TableStats{Rows: 1000},
+		},
+	})
+
+	tests := []struct {
+		name string
+		g    *ErdGenerator
+	}{
+		{
+			name: "with --table",
+			g:    &ErdGenerator{SortBy: "rows", Table: "articles", Distance: 1, Format: "mermaid"},
+		},
+		{
+			name: "with --from and --to",
+			g:    &ErdGenerator{SortBy: "rows", From: "articles", To: "users", Format: "mermaid"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := tt.g.prepareRender(schema)
+			require.NoError(t, err)
+
+			var tableNames []string
+			for _, table := range got.Tables {
+				tableNames = append(tableNames, table.Name)
+			}
+			assert.Equal(t, []string{"users", "articles"}, tableNames)
+		})
+	}
+}
+
 func TestErdGenerator_checkParamTable(t *testing.T) {
 	type fields struct {
 		Filepath string
 		Table    string
 		Distance int
+		From     string
+		To       string
 	}
 	type args struct {
 		schema *db.Schema
@@ -414,6 +1004,43 @@ func TestErdGenerator_checkParamTable(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "--from and --to are passed and tables are exists",
+			fields: fields{
+				From: "articles",
+				To:   "users",
+			},
+			args: args{
+				schema: &db.Schema{
+					Tables: []*db.Table{
+						{
+							Name: "articles",
+						},
+						{
+							Name: "users",
+						},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "--to is passed and table is not exists",
+			fields: fields{
+				From: "articles",
+				To:   "users",
+			},
+			args: args{
+				schema: &db.Schema{
+					Tables: []*db.Table{
+						{
+							Name: "articles",
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -421,6 +1048,8 @@ func TestErdGenerator_checkParamTable(t *testing.T) {
 				Filepath: tt.fields.Filepath,
 				Table:    tt.fields.Table,
 				Distance: tt.fields.Distance,
+				From:     tt.fields.From,
+				To:       tt.fields.To,
 			}
 
 			err := g.checkParamTable(tt.args.schema)
@@ -514,7 +1143,7 @@ func ExampleErdGenerator_Run_two_tables_with_PlantUML() {
 		);`)
 		a.DB.MustExec("CREATE INDEX index_user_id_on_articles ON articles(user_id)")
 
-		schema, err := LoadSchema(a)
+		schema, err := LoadSchema(a, NewLoadSchemaOption())
 		if err != nil {
 			panic(err)
 		}
@@ -548,7 +1177,7 @@ func ExampleErdGenerator_Run_many_tables_with_PlantUML() {
 	withDatabase(func(a *sqlite3.Adapter) {
 		createManyExampleTables(a)
 
-		schema, err := LoadSchema(a)
+		schema, err := LoadSchema(a, NewLoadSchemaOption())
 		if err != nil {
 			panic(err)
 		}
@@ -642,7 +1271,7 @@ func ExampleErdGenerator_Run_many_tables_within_a_distance_of_1_from_the_article
 	withDatabase(func(a *sqlite3.Adapter) {
 		createManyExampleTables(a)
 
-		schema, err := LoadSchema(a)
+		schema, err := LoadSchema(a, NewLoadSchemaOption())
 		if err != nil {
 			panic(err)
 		}
@@ -720,7 +1349,7 @@ func ExampleErdGenerator_Run_two_tables_with_Mermaid() {
 		);`)
 		a.DB.MustExec("CREATE INDEX index_user_id_on_articles ON articles(user_id)")
 
-		schema, err := LoadSchema(a)
+		schema, err := LoadSchema(a, NewLoadSchemaOption())
 		if err != nil {
 			panic(err)
 		}
@@ -752,7 +1381,7 @@ func ExampleErdGenerator_Run_many_tables_with_Mermaid() {
 	withDatabase(func(a *sqlite3.Adapter) {
 		createManyExampleTables(a)
 
-		schema, err := LoadSchema(a)
+		schema, err := LoadSchema(a, NewLoadSchemaOption())
 		if err != nil {
 			panic(err)
 		}
@@ -827,7 +1456,7 @@ func ExampleErdGenerator_Run_many_tables_within_a_distance_of_1_from_the_article
 	withDatabase(func(a *sqlite3.Adapter) {
 		createManyExampleTables(a)
 
-		schema, err := LoadSchema(a)
+		schema, err := LoadSchema(a, NewLoadSchemaOption())
 		if err != nil {
 			panic(err)
 		}
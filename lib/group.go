@@ -0,0 +1,107 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/cockroachdb/errors"
+	"github.com/sue445/plant_erd/db"
+	"os"
+	"path"
+	"strings"
+)
+
+// GroupConfig represents config file which maps tables to groups
+//
+// e.g.
+//
+//	{
+//	  "groups": [
+//	    {"name": "billing", "tables": ["billing_*", "invoices"]}
+//	  ]
+//	}
+type GroupConfig struct {
+	Groups []*GroupRule `json:"groups"`
+}
+
+// GroupRule represents tables which belong to a group
+type GroupRule struct {
+	Name string `json:"name"`
+
+	// Tables represents glob patterns of table name (e.g. billing_*)
+	Tables []string `json:"tables"`
+}
+
+// LoadGroupConfig returns GroupConfig which is read from JSON file
+func LoadGroupConfig(configPath string) (*GroupConfig, error) {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var config GroupConfig
+	err = json.Unmarshal(content, &config)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, rule := range config.Groups {
+		for _, pattern := range rule.Tables {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("%s is invalid table pattern of group %s", pattern, rule.Name)
+			}
+		}
+	}
+
+	return &config, nil
+}
+
+// groupName returns name of the first group which table matches with. This returns empty when table doesn't match any groups
+func (c *GroupConfig) groupName(tableName string) string {
+	for _, rule := range c.Groups {
+		for _, pattern := range rule.Tables {
+			if matched, _ := path.Match(pattern, tableName); matched {
+				return rule.Name
+			}
+		}
+	}
+	return ""
+}
+
+// schemaGroupName returns schema name of qualified table name (e.g. public.users -> public)
+func schemaGroupName(tableName string) string {
+	schemaName, _, found := strings.Cut(tableName, ".")
+	if !found {
+		return ""
+	}
+	return schemaName
+}
+
+// prefixGroupName returns the first word of snake_case table name (e.g. billing_invoices -> billing)
+func prefixGroupName(tableName string) string {
+	prefix, _, found := strings.Cut(tableName, "_")
+	if !found {
+		return ""
+	}
+	return prefix
+}
+
+// groupTables returns tables which Group is set with groupName.
+// A group which has only one table is ignored when ignoreSingle is true, because prefix of such table is merely a part of its name
+func groupTables(tables []*db.Table, groupName func(tableName string) string, ignoreSingle bool) []*db.Table {
+	counts := map[string]int{}
+	for _, table := range tables {
+		counts[groupName(table.Name)]++
+	}
+
+	var grouped []*db.Table
+	for _, table := range tables {
+		copied := *table
+		copied.Group = groupName(table.Name)
+		if ignoreSingle && counts[copied.Group] < 2 {
+			copied.Group = ""
+		}
+		grouped = append(grouped, &copied)
+	}
+
+	return grouped
+}
@@ -0,0 +1,146 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sue445/plant_erd/db"
+)
+
+func groupNames(schema *db.Schema) map[string]string {
+	names := map[string]string{}
+	for _, table := range schema.Tables {
+		names[table.Name] = table.Group
+	}
+	return names
+}
+
+func TestErdGenerator_groupSchema(t *testing.T) {
+	schema := db.NewSchema([]*db.Table{
+		{Name: "public.users"},
+		{Name: "billing.invoices"},
+		{Name: "billing_invoices"},
+		{Name: "billing_payments"},
+		{Name: "user_tags"},
+		{Name: "tags"},
+	})
+
+	configPath := writeTempFile(t, "groups.json", `{
+  "groups": [
+    {"name": "billing", "tables": ["billing_*", "billing.*"]},
+    {"name": "tagging", "tables": ["tags", "*_tags"]}
+  ]
+}`)
+
+	tests := []struct {
+		name        string
+		groupBy     string
+		groupConfig string
+		want        map[string]string
+	}{
+		{
+			name:    "schema",
+			groupBy: "schema",
+			want: map[string]string{
+				"public.users":     "public",
+				"billing.invoices": "billing",
+				"billing_invoices": "",
+				"billing_payments": "",
+				"user_tags":        "",
+				"tags":             "",
+			},
+		},
+		{
+			name:    "prefix",
+			groupBy: "prefix",
+			want: map[string]string{
+				"public.users":     "",
+				"billing.invoices": "",
+				"billing_invoices": "billing",
+				"billing_payments": "billing",
+				"user_tags":        "",
+				"tags":             "",
+			},
+		},
+		{
+			name:        "config",
+			groupConfig: configPath,
+			want: map[string]string{
+				"public.users":     "",
+				"billing.invoices": "billing",
+				"billing_invoices": "billing",
+				"billing_payments": "billing",
+				"user_tags":        "tagging",
+				"tags":             "tagging",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &ErdGenerator{GroupBy: tt.groupBy, GroupConfig: tt.groupConfig}
+			got, err := g.groupSchema(schema)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, groupNames(got))
+			}
+		})
+	}
+
+	t.Run("original schema isn't modified", func(t *testing.T) {
+		g := &ErdGenerator{GroupBy: "schema"}
+		_, err := g.groupSchema(schema)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "", schema.Tables[0].Group)
+		}
+	})
+
+	t.Run("unknown group key", func(t *testing.T) {
+		g := &ErdGenerator{GroupBy: "unknown"}
+		_, err := g.groupSchema(schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown is unknown group key")
+	})
+
+	t.Run("both --group-by and --group-config", func(t *testing.T) {
+		g := &ErdGenerator{GroupBy: "schema", GroupConfig: configPath}
+		_, err := g.groupSchema(schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--group-by and --group-config cannot be used together")
+	})
+}
+
+func TestLoadGroupConfig(t *testing.T) {
+	t.Run("invalid pattern", func(t *testing.T) {
+		configPath := writeTempFile(t, "groups.json", `{"groups": [{"name": "billing", "tables": ["billing_["]}]}`)
+		_, err := LoadGroupConfig(configPath)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "billing_[ is invalid table pattern of group billing")
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		configPath := writeTempFile(t, "groups.json", `groups:`)
+		_, err := LoadGroupConfig(configPath)
+		assert.Error(t, err)
+	})
+}
+
+func TestErdGenerator_generate_withGroup(t *testing.T) {
+	schema := db.NewSchema([]*db.Table{
+		{Name: "billing_invoices"},
+		{Name: "billing_payments"},
+		{Name: "users"},
+	})
+
+	g := &ErdGenerator{GroupBy: "prefix", GroupStyle: "namespace"}
+	got, err := g.generate(schema)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "namespace billing {\n\n  entity billing_invoices {\n  }\n\n  entity billing_payments {\n  }\n\n}\n\nentity users {\n}", got)
+	}
+
+	t.Run("unknown group style", func(t *testing.T) {
+		g := &ErdGenerator{GroupBy: "prefix", GroupStyle: "unknown"}
+		_, err := g.generate(schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown is unknown group style")
+	})
+}
@@ -0,0 +1,127 @@
+package lib
+
+import (
+	"bytes"
+	_ "embed" // for go:embed
+	"encoding/json"
+	"github.com/cockroachdb/errors"
+	"github.com/sue445/plant_erd/db"
+	"html/template"
+)
+
+var (
+	//go:embed assets/explorer.html
+	explorerHTML string
+
+	//go:embed assets/explorer.css
+	explorerCSS string
+
+	//go:embed assets/explorer.js
+	explorerJS string
+
+	explorerTemplate = template.Must(template.New("explorer").Parse(explorerHTML))
+)
+
+type htmlSchema struct {
+	Tables []*htmlTable `json:"tables"`
+}
+
+type htmlTable struct {
+	Name        string            `json:"name"`
+	Columns     []*htmlColumn     `json:"columns"`
+	Indexes     []*htmlIndex      `json:"indexes,omitempty"`
+	ForeignKeys []*htmlForeignKey `json:"foreignKeys,omitempty"`
+	Stats       string            `json:"stats,omitempty"`
+
+	// Distances represents result of SchemaExplorer.Distances. Tables within a distance are the same as SchemaExplorer.Explore
+	Distances map[string]int `json:"distances"`
+}
+
+type htmlColumn struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	NotNull    bool   `json:"notNull,omitempty"`
+	PrimaryKey bool   `json:"primaryKey,omitempty"`
+	Default    string `json:"default,omitempty"`
+}
+
+type htmlIndex struct {
+	Name       string `json:"name"`
+	Definition string `json:"definition"`
+	Unique     bool   `json:"unique,omitempty"`
+}
+
+type htmlForeignKey struct {
+	FromColumn string `json:"fromColumn"`
+	ToTable    string `json:"toTable"`
+	ToColumn   string `json:"toColumn"`
+	Inferred   bool   `json:"inferred,omitempty"`
+}
+
+// generateHTML returns self-contained HTML which explores schema
+func generateHTML(schema *db.Schema) (string, error) {
+	data, err := json.Marshal(newHTMLSchema(schema))
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	var buf bytes.Buffer
+	err = explorerTemplate.Execute(&buf, map[string]any{
+		"CSS":  template.CSS(explorerCSS), //nolint:gosec
+		"JS":   template.JS(explorerJS),   //nolint:gosec
+		"Data": template.JS(data),         //nolint:gosec
+	})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return buf.String(), nil
+}
+
+func newHTMLSchema(schema *db.Schema) *htmlSchema {
+	explorer := db.NewSchemaExplorer(schema)
+	s := &htmlSchema{Tables: []*htmlTable{}}
+
+	for _, table := range schema.Tables {
+		t := &htmlTable{
+			Name:      table.Name,
+			Columns:   []*htmlColumn{},
+			Distances: explorer.Distances(table.Name),
+		}
+
+		if table.Stats != nil {
+			t.Stats = table.Stats.String()
+		}
+
+		for _, column := range table.Columns {
+			t.Columns = append(t.Columns, &htmlColumn{
+				Name:       column.Name,
+				Type:       column.Type,
+				NotNull:    column.NotNull,
+				PrimaryKey: column.PrimaryKey,
+				Default:    column.Default,
+			})
+		}
+
+		for _, foreignKey := range table.ForeignKeys {
+			t.ForeignKeys = append(t.ForeignKeys, &htmlForeignKey{
+				FromColumn: foreignKey.FromColumn,
+				ToTable:    foreignKey.ToTable,
+				ToColumn:   foreignKey.ToColumn,
+				Inferred:   foreignKey.Inferred,
+			})
+		}
+
+		for _, index := range table.Indexes {
+			t.Indexes = append(t.Indexes, &htmlIndex{
+				Name:       index.Name,
+				Definition: index.Definition(),
+				Unique:     index.Unique,
+			})
+		}
+
+		s.Tables = append(s.Tables, t)
+	}
+
+	return s
+}
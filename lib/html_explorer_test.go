@@ -0,0 +1,87 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sue445/plant_erd/db"
+)
+
+func newHTMLTestSchema() *db.Schema {
+	return db.NewSchema([]*db.Table{
+		{
+			Name: "articles",
+			Columns: []*db.Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+				{Name: "user_id", Type: "integer", NotNull: true},
+			},
+			ForeignKeys: []*db.ForeignKey{
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+			},
+		},
+		{
+			Name: "comments",
+			Columns: []*db.Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+				{Name: "article_id", Type: "integer", NotNull: true},
+			},
+			ForeignKeys: []*db.ForeignKey{
+				{FromColumn: "article_id", ToTable: "articles", ToColumn: "id"},
+			},
+		},
+		{
+			Name: "users",
+			Columns: []*db.Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+				{Name: "name", Type: "text", Default: "'</script>'"},
+			},
+			Indexes: []*db.Index{
+				{Name: "index_name_on_users", Columns: []string{"name"}, Unique: true},
+				{Name: "index_name_prefix_on_users", Columns: []string{"name"}, Method: "btree", Lengths: []int{10}},
+				{Name: "index_lower_name_on_users", Columns: []string{"id"}, Method: "gin", KeyParts: []*db.IndexKeyPart{{Expression: "lower(name)"}, {Column: "id"}}},
+			},
+		},
+		{
+			Name: "logs",
+			Columns: []*db.Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+			},
+		},
+	})
+}
+
+func Test_generateHTML(t *testing.T) {
+	got, err := generateHTML(newHTMLTestSchema())
+	require.NoError(t, err)
+
+	assert.Contains(t, got, "<!DOCTYPE html>")
+	assert.Contains(t, got, `<script type="application/json" id="schema-data">{"tables":[{"name":"articles",`)
+	assert.Contains(t, got, `"foreignKeys":[{"fromColumn":"user_id","toTable":"users","toColumn":"id"}]`)
+	assert.Contains(t, got, `"indexes":[{"name":"index_name_on_users","definition":"(name)","unique":true},{"name":"index_name_prefix_on_users","definition":"(name(10))"},{"name":"index_lower_name_on_users","definition":"(lower(name), id) USING gin"}]`)
+	// "</script>" in data must be escaped
+	assert.Contains(t, got, `"default":"'\u003c/script\u003e'"`)
+	assert.Contains(t, got, `var schema = JSON.parse(`)
+	assert.Contains(t, got, `#sidebar {`)
+
+	// Embedded JS and CSS must not be escaped
+	assert.NotContains(t, got, "ZgotmplZ")
+	assert.NotContains(t, got, "&#34;")
+}
+
+func Test_newHTMLSchema(t *testing.T) {
+	got := newHTMLSchema(newHTMLTestSchema())
+
+	distances := map[string]map[string]int{}
+	for _, table := range got.Tables {
+		distances[table.Name] = table.Distances
+	}
+
+	want := map[string]map[string]int{
+		"articles": {"articles": 0, "comments": 1, "users": 1},
+		"comments": {"articles": 1, "comments": 0, "users": 2},
+		"users":    {"articles": 1, "comments": 2, "users": 0},
+		"logs":     {"logs": 0},
+	}
+	assert.Equal(t, want, distances)
+}
@@ -0,0 +1,290 @@
+package lib
+
+import (
+	"fmt"
+	"github.com/sue445/plant_erd/db"
+	"strings"
+)
+
+const (
+	// LintSeverityError represents severity of problem which should be fixed
+	LintSeverityError = "error"
+
+	// LintSeverityWarning represents severity of problem which may cause troubles
+	LintSeverityWarning = "warning"
+
+	// LintSeverityNote represents severity of problem which is a matter of style
+	LintSeverityNote = "note"
+)
+
+// lintSeverityLevels represents order of severities
+var lintSeverityLevels = map[string]int{
+	LintSeverityNote:    1,
+	LintSeverityWarning: 2,
+	LintSeverityError:   3,
+}
+
+const (
+	// DefaultLintTablePattern represents default regex pattern of table name which is checked with naming-convention rule
+	DefaultLintTablePattern = "^[a-z][a-z0-9_]*$"
+
+	// DefaultLintColumnPattern represents default regex pattern of column name which is checked with naming-convention rule
+	DefaultLintColumnPattern = "^[a-z][a-z0-9_]*$"
+)
+
+// lintRule represents a rule which checks each table of schema
+type lintRule struct {
+	id          string
+	description string
+	severity    string
+
+	// check returns findings of table. resolver finds other tables of schema. Rule and Severity of findings are filled by Linter
+	check func(config *LintRuleConfig, resolver *db.TableResolver, table *db.Table) []*LintFinding
+}
+
+var lintRules = []*lintRule{
+	{
+		id:          "no-primary-key",
+		description: "Table doesn't have primary key",
+		severity:    LintSeverityWarning,
+		check:       checkNoPrimaryKey,
+	},
+	{
+		id:          "unindexed-foreign-key",
+		description: "Foreign key columns don't have an index whose leading columns are the same",
+		severity:    LintSeverityWarning,
+		check:       checkUnindexedForeignKey,
+	},
+	{
+		id:          "missing-referenced-table",
+		description: "Foreign key refers a table or a column which doesn't exist in schema",
+		severity:    LintSeverityError,
+		check:       checkMissingReferencedTable,
+	},
+	{
+		id:          "nullable-foreign-key",
+		description: "Foreign key column is nullable",
+		severity:    LintSeverityNote,
+		check:       checkNullableForeignKey,
+	},
+	{
+		id:          "redundant-index",
+		description: "Index is the same as another index or is covered by leading columns of another index",
+		severity:    LintSeverityWarning,
+		check:       checkRedundantIndex,
+	},
+	{
+		id:          "naming-convention",
+		description: "Table name or column name doesn't match with naming convention",
+		severity:    LintSeverityNote,
+		check:       checkNamingConvention,
+	},
+}
+
+func findLintRule(id string) *lintRule {
+	for _, rule := range lintRules {
+		if rule.id == id {
+			return rule
+		}
+	}
+	return nil
+}
+
+func checkNoPrimaryKey(_ *LintRuleConfig, _ *db.TableResolver, table *db.Table) []*LintFinding {
+	if len(table.GetPrimaryKeyColumns()) > 0 {
+		return nil
+	}
+
+	return []*LintFinding{
+		{Table: table.Name, Message: "table doesn't have primary key"},
+	}
+}
+
+func checkUnindexedForeignKey(_ *LintRuleConfig, _ *db.TableResolver, table *db.Table) []*LintFinding {
+	// Primary key can be used as an index too
+	var candidates [][]string
+	var pkColumns []string
+	for _, column := range table.GetPrimaryKeyColumns() {
+		pkColumns = append(pkColumns, column.Name)
+	}
+	candidates = append(candidates, pkColumns)
+
+	for _, index := range table.Indexes {
+		// Expression index and partial index don't support all lookups of foreign key
+		if len(index.Expressions()) > 0 || index.Predicate != "" {
+			continue
+		}
+		candidates = append(candidates, index.Columns)
+	}
+
+	var findings []*LintFinding
+	for _, foreignKeys := range table.ForeignKeyConstraints() {
+		var columns []string
+		for _, foreignKey := range foreignKeys {
+			columns = append(columns, foreignKey.FromColumn)
+		}
+
+		indexed := false
+		for _, candidate := range candidates {
+			if len(candidate) >= len(columns) && sameColumnSet(candidate[:len(columns)], columns) {
+				indexed = true
+				break
+			}
+		}
+
+		if !indexed {
+			findings = append(findings, &LintFinding{
+				Table:   table.Name,
+				Column:  strings.Join(columns, ", "),
+				Message: fmt.Sprintf("foreign key (%s) doesn't have supporting index", strings.Join(columns, ", ")),
+			})
+		}
+	}
+
+	return findings
+}
+
+func checkMissingReferencedTable(_ *LintRuleConfig, resolver *db.TableResolver, table *db.Table) []*LintFinding {
+	var findings []*LintFinding
+	for _, foreignKey := range table.ForeignKeys {
+		toTable := resolver.Find(foreignKey.ToTable)
+		if toTable == nil {
+			findings = append(findings, &LintFinding{
+				Table:   table.Name,
+				Column:  foreignKey.FromColumn,
+				Message: fmt.Sprintf("foreign key refers missing table %s", foreignKey.ToTable),
+			})
+			continue
+		}
+
+		if len(toTable.Columns) > 0 && toTable.FindColumnIgnoreCase(foreignKey.ToColumn) == nil {
+			findings = append(findings, &LintFinding{
+				Table:   table.Name,
+				Column:  foreignKey.FromColumn,
+				Message: fmt.Sprintf("foreign key refers missing column %s.%s", foreignKey.ToTable, foreignKey.ToColumn),
+			})
+		}
+	}
+
+	return findings
+}
+
+func checkNullableForeignKey(_ *LintRuleConfig, _ *db.TableResolver, table *db.Table) []*LintFinding {
+	checked := map[string]bool{}
+
+	var findings []*LintFinding
+	for _, foreignKey := range table.ForeignKeys {
+		column := table.FindColumnIgnoreCase(foreignKey.FromColumn)
+		if column == nil || column.NotNull || column.PrimaryKey || checked[column.Name] {
+			continue
+		}
+		checked[column.Name] = true
+
+		findings = append(findings, &LintFinding{
+			Table:   table.Name,
+			Column:  column.Name,
+			Message: fmt.Sprintf("foreign key column %s is nullable", column.Name),
+		})
+	}
+
+	return findings
+}
+
+func checkRedundantIndex(_ *LintRuleConfig, _ *db.TableResolver, table *db.Table) []*LintFinding {
+	var findings []*LintFinding
+	for i, index := range table.Indexes {
+		if !isPlainIndex(index) {
+			continue
+		}
+
+		for j, other := range table.Indexes {
+			if i == j || !isPlainIndex(other) || !strings.EqualFold(index.Method, other.Method) {
+				continue
+			}
+
+			if len(index.Columns) == len(other.Columns) && sameKeyParts(index, other, len(index.Columns)) {
+				// Unique index is kept when uniqueness is different. Otherwise the latter is reported
+				if (index.Unique == other.Unique && j < i) || (!index.Unique && other.Unique) {
+					findings = append(findings, &LintFinding{
+						Table:   table.Name,
+						Column:  strings.Join(index.Columns, ", "),
+						Message: fmt.Sprintf("index %s is duplicate of %s", index.Name, other.Name),
+					})
+					break
+				}
+				continue
+			}
+
+			// Unique index isn't redundant because it is a constraint
+			if !index.Unique && len(index.Columns) < len(other.Columns) && sameKeyParts(index, other, len(index.Columns)) {
+				findings = append(findings, &LintFinding{
+					Table:   table.Name,
+					Column:  strings.Join(index.Columns, ", "),
+					Message: fmt.Sprintf("index %s is redundant with %s", index.Name, other.Name),
+				})
+				break
+			}
+		}
+	}
+
+	return findings
+}
+
+func checkNamingConvention(config *LintRuleConfig, _ *db.TableResolver, table *db.Table) []*LintFinding {
+	var findings []*LintFinding
+
+	// Schema name isn't checked (e.g. public of public.users)
+	tableName := table.Name[strings.LastIndex(table.Name, ".")+1:]
+	if !config.tableRe.MatchString(tableName) {
+		findings = append(findings, &LintFinding{
+			Table:   table.Name,
+			Message: fmt.Sprintf("table name %s doesn't match %s", tableName, config.tableRe),
+		})
+	}
+
+	for _, column := range table.Columns {
+		if !config.columnRe.MatchString(column.Name) {
+			findings = append(findings, &LintFinding{
+				Table:   table.Name,
+				Column:  column.Name,
+				Message: fmt.Sprintf("column name %s doesn't match %s", column.Name, config.columnRe),
+			})
+		}
+	}
+
+	return findings
+}
+
+// isPlainIndex returns whether index consists of only columns without condition
+func isPlainIndex(index *db.Index) bool {
+	return len(index.Columns) > 0 && len(index.Expressions()) == 0 && index.Predicate == ""
+}
+
+// sameKeyParts returns whether leading n key parts of 2 indexes are the same including order of columns, sort order and prefix length
+func sameKeyParts(index *db.Index, other *db.Index, n int) bool {
+	for i := 0; i < n; i++ {
+		if !strings.EqualFold(index.Columns[i], other.Columns[i]) || !strings.EqualFold(index.Order(i), other.Order(i)) || index.Length(i) != other.Length(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameColumnSet returns whether 2 column lists are the same regardless of order
+func sameColumnSet(columns []string, others []string) bool {
+	if len(columns) != len(others) {
+		return false
+	}
+
+	counts := map[string]int{}
+	for _, column := range columns {
+		counts[strings.ToLower(column)]++
+	}
+	for _, column := range others {
+		counts[strings.ToLower(column)]--
+		if counts[strings.ToLower(column)] < 0 {
+			return false
+		}
+	}
+	return true
+}
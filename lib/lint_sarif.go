@@ -0,0 +1,113 @@
+package lib
+
+import "fmt"
+
+// c.f. https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+)
+
+type sarifLog struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    *sarifTool     `json:"tool"`
+	Results []*sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver *sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string       `json:"name"`
+	InformationURI string       `json:"informationUri"`
+	Rules          []*sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string                  `json:"id"`
+	ShortDescription     *sarifMessage           `json:"shortDescription"`
+	DefaultConfiguration *sarifRuleConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifRuleConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string           `json:"ruleId"`
+	Level     string           `json:"level"`
+	Message   *sarifMessage    `json:"message"`
+	Locations []*sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation  `json:"physicalLocation,omitempty"`
+	LogicalLocations []*sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation *sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// newSarifLog returns SARIF log of findings. Findings are located with table and column names (logical location) and sourceURI (physical location) when it isn't empty
+func newSarifLog(rules []*lintRule, ruleConfigs []*LintRuleConfig, findings []*LintFinding, sourceURI string) *sarifLog {
+	driver := &sarifDriver{
+		Name:           "plant_erd",
+		InformationURI: "https://github.com/sue445/plant_erd",
+		Rules:          []*sarifRule{},
+	}
+	for i, rule := range rules {
+		driver.Rules = append(driver.Rules, &sarifRule{
+			ID:                   rule.id,
+			ShortDescription:     &sarifMessage{Text: rule.description},
+			DefaultConfiguration: &sarifRuleConfiguration{Level: ruleConfigs[i].Severity},
+		})
+	}
+
+	results := []*sarifResult{}
+	for _, finding := range findings {
+		logicalLocation := &sarifLogicalLocation{Name: finding.Table, FullyQualifiedName: finding.Table, Kind: "table"}
+		if finding.Column != "" {
+			logicalLocation = &sarifLogicalLocation{Name: finding.Column, FullyQualifiedName: finding.Table + "." + finding.Column, Kind: "column"}
+		}
+
+		location := &sarifLocation{LogicalLocations: []*sarifLogicalLocation{logicalLocation}}
+		if sourceURI != "" {
+			location.PhysicalLocation = &sarifPhysicalLocation{ArtifactLocation: &sarifArtifactLocation{URI: sourceURI}}
+		}
+
+		results = append(results, &sarifResult{
+			RuleID:    finding.Rule,
+			Level:     finding.Severity,
+			Message:   &sarifMessage{Text: fmt.Sprintf("%s: %s", logicalLocation.FullyQualifiedName, finding.Message)},
+			Locations: []*sarifLocation{location},
+		})
+	}
+
+	return &sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []*sarifRun{{Tool: &sarifTool{Driver: driver}, Results: results}},
+	}
+}
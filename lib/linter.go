@@ -0,0 +1,283 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/cockroachdb/errors"
+	"github.com/sue445/plant_erd/db"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// LintFinding represents a problem of schema which is found with lint rule
+type LintFinding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Table    string `json:"table"`
+
+	// Column represents column name of problem. Columns are joined with comma when problem has multiple columns (e.g. composite foreign key)
+	Column string `json:"column,omitempty"`
+
+	Message string `json:"message"`
+}
+
+// LintConfig represents config file which customizes lint rules
+//
+// e.g.
+//
+//	{
+//	  "rules": {
+//	    "nullable-foreign-key": {"enabled": false},
+//	    "no-primary-key": {"severity": "error"},
+//	    "naming-convention": {"table": "^[a-z][a-z0-9_]*s$", "column": "^[a-z][a-z0-9_]*$"}
+//	  }
+//	}
+type LintConfig struct {
+	Rules map[string]*LintRuleConfig `json:"rules"`
+}
+
+// LintRuleConfig represents config of lint rule
+type LintRuleConfig struct {
+	// Enabled represents whether rule is enabled. Rule is enabled when this is omitted
+	Enabled *bool `json:"enabled"`
+
+	// Severity represents severity of findings (error, warning, note). Default severity of rule is used when this is empty
+	Severity string `json:"severity"`
+
+	// Table represents regex pattern of table name. This is used only naming-convention rule (default: DefaultLintTablePattern)
+	Table string `json:"table"`
+
+	// Column represents regex pattern of column name. This is used only naming-convention rule (default: DefaultLintColumnPattern)
+	Column string `json:"column"`
+
+	tableRe  *regexp.Regexp
+	columnRe *regexp.Regexp
+}
+
+// LoadLintConfig returns LintConfig which is read from JSON file
+func LoadLintConfig(configPath string) (*LintConfig, error) {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var config LintConfig
+	err = json.Unmarshal(content, &config)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for id, ruleConfig := range config.Rules {
+		if findLintRule(id) == nil {
+			return nil, fmt.Errorf("%s is unknown lint rule", id)
+		}
+
+		if ruleConfig == nil {
+			return nil, fmt.Errorf("config of lint rule %s is empty", id)
+		}
+
+		if ruleConfig.Severity != "" {
+			if _, ok := lintSeverityLevels[ruleConfig.Severity]; !ok {
+				return nil, fmt.Errorf("%s is unknown severity of lint rule %s. severity must be error, warning or note", ruleConfig.Severity, id)
+			}
+		}
+	}
+
+	return &config, nil
+}
+
+// ruleConfig returns config of rule which default values are filled
+func (c *LintConfig) ruleConfig(rule *lintRule) (*LintRuleConfig, error) {
+	config := LintRuleConfig{}
+	if c != nil && c.Rules[rule.id] != nil {
+		config = *c.Rules[rule.id]
+	}
+
+	if config.Severity == "" {
+		config.Severity = rule.severity
+	}
+	if config.Table == "" {
+		config.Table = DefaultLintTablePattern
+	}
+	if config.Column == "" {
+		config.Column = DefaultLintColumnPattern
+	}
+
+	var err error
+	config.tableRe, err = regexp.Compile(config.Table)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	config.columnRe, err = regexp.Compile(config.Column)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &config, nil
+}
+
+// Linter represents schema linter which checks schema with rules
+type Linter struct {
+	Filepath string
+
+	// Format represents output format (text, json, sarif)
+	Format string
+
+	// Config represents path of LintConfig file
+	Config string
+
+	// FailLevel represents the lowest severity which fails lint (error, warning, note)
+	FailLevel string
+
+	// SourceURI represents URI of schema file (e.g. db/schema.rb). This is used as location of SARIF
+	SourceURI string
+}
+
+// NewLinter returns a new Linter instance
+func NewLinter() *Linter {
+	return &Linter{}
+}
+
+// Run checks schema with rules and outputs findings. This returns whether findings of FailLevel or higher severity are found
+func (l *Linter) Run(schema *db.Schema) (bool, error) {
+	failLevel := LintSeverityNote
+	if l.FailLevel != "" {
+		failLevel = l.FailLevel
+	}
+	if _, ok := lintSeverityLevels[failLevel]; !ok {
+		return false, fmt.Errorf("%s is unknown fail level. fail level must be error, warning or note", failLevel)
+	}
+
+	rules, ruleConfigs, err := l.enabledRules()
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	findings := lintSchema(schema, rules, ruleConfigs)
+
+	content, err := l.format(findings, rules, ruleConfigs)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	err = writeOutput(l.Filepath, content)
+	if err != nil {
+		return false, errors.WithStack(err)
+	}
+
+	for _, finding := range findings {
+		if lintSeverityLevels[finding.Severity] >= lintSeverityLevels[failLevel] {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// enabledRules returns enabled rules and their configs
+func (l *Linter) enabledRules() ([]*lintRule, []*LintRuleConfig, error) {
+	var config *LintConfig
+	if l.Config != "" {
+		loaded, err := LoadLintConfig(l.Config)
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+		config = loaded
+	}
+
+	var rules []*lintRule
+	var ruleConfigs []*LintRuleConfig
+	for _, rule := range lintRules {
+		ruleConfig, err := config.ruleConfig(rule)
+		if err != nil {
+			return nil, nil, errors.WithStack(err)
+		}
+
+		if ruleConfig.Enabled != nil && !*ruleConfig.Enabled {
+			continue
+		}
+
+		rules = append(rules, rule)
+		ruleConfigs = append(ruleConfigs, ruleConfig)
+	}
+
+	return rules, ruleConfigs, nil
+}
+
+// lintSchema returns findings of all tables in order of table and rule
+func lintSchema(schema *db.Schema, rules []*lintRule, ruleConfigs []*LintRuleConfig) []*LintFinding {
+	resolver := db.NewTableResolver(schema.Tables)
+
+	findings := []*LintFinding{}
+	for _, table := range schema.Tables {
+		for i, rule := range rules {
+			for _, finding := range rule.check(ruleConfigs[i], resolver, table) {
+				finding.Rule = rule.id
+				finding.Severity = ruleConfigs[i].Severity
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	return findings
+}
+
+func (l *Linter) format(findings []*LintFinding, rules []*lintRule, ruleConfigs []*LintRuleConfig) (string, error) {
+	switch l.Format {
+	case "", "text":
+		return formatLintText(findings), nil
+	case "json":
+		content, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+		return string(content) + "\n", nil
+	case "sarif":
+		content, err := json.MarshalIndent(newSarifLog(rules, ruleConfigs, findings, l.SourceURI), "", "  ")
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+		return string(content) + "\n", nil
+	}
+
+	return "", fmt.Errorf("%s is unknown lint format. lint format must be text, json or sarif", l.Format)
+}
+
+// formatLintText returns findings as lines of "location: severity: message [rule]" and summary. This returns empty string when there are no findings
+func formatLintText(findings []*LintFinding) string {
+	if len(findings) == 0 {
+		return ""
+	}
+
+	var lines []string
+	counts := map[string]int{}
+	for _, finding := range findings {
+		location := finding.Table
+		if finding.Column != "" && !strings.Contains(finding.Column, ",") {
+			location += "." + finding.Column
+		}
+
+		lines = append(lines, fmt.Sprintf("%s: %s: %s [%s]", location, finding.Severity, finding.Message, finding.Rule))
+		counts[finding.Severity]++
+	}
+
+	var summaries []string
+	for _, severity := range []string{LintSeverityError, LintSeverityWarning, LintSeverityNote} {
+		if counts[severity] > 0 {
+			summaries = append(summaries, fmt.Sprintf("%d %s", counts[severity], pluralizeCount(counts[severity], severity)))
+		}
+	}
+
+	lines = append(lines, "", fmt.Sprintf("%d %s (%s)", len(findings), pluralizeCount(len(findings), "problem"), strings.Join(summaries, ", ")))
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func pluralizeCount(count int, word string) string {
+	if count == 1 {
+		return word
+	}
+	return db.Pluralize(word)
+}
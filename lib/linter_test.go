@@ -0,0 +1,358 @@
+package lib
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sue445/plant_erd/db"
+)
+
+func lintTestSchema() *db.Schema {
+	return db.NewSchema([]*db.Table{
+		{
+			Name: "articles",
+			Columns: []*db.Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+				{Name: "user_id", Type: "integer", NotNull: true},
+				{Name: "editor_id", Type: "integer"},
+				{Name: "category_id", Type: "integer", NotNull: true},
+				{Name: "Title", Type: "text"},
+			},
+			ForeignKeys: []*db.ForeignKey{
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+				{FromColumn: "editor_id", ToTable: "users", ToColumn: "id"},
+				{FromColumn: "category_id", ToTable: "categories", ToColumn: "id"},
+			},
+			Indexes: []*db.Index{
+				{Name: "index_articles_on_user_id", Columns: []string{"user_id"}},
+				{Name: "index_articles_on_user_id_and_title", Columns: []string{"user_id", "Title"}},
+				{Name: "index_articles_on_editor_id", Columns: []string{"editor_id"}, Predicate: "editor_id IS NOT NULL"},
+			},
+		},
+		{
+			Name: "users",
+			Columns: []*db.Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+				{Name: "email", Type: "text", NotNull: true},
+			},
+			Indexes: []*db.Index{
+				{Name: "index_users_on_email", Columns: []string{"email"}, Unique: true},
+				{Name: "index_users_on_email_2", Columns: []string{"email"}},
+			},
+		},
+		{
+			Name: "UserLogs",
+			Columns: []*db.Column{
+				{Name: "user_id", Type: "integer", NotNull: true},
+			},
+			ForeignKeys: []*db.ForeignKey{
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "uuid"},
+			},
+			Indexes: []*db.Index{
+				{Name: "index_user_logs_on_user_id", Columns: []string{"user_id"}},
+			},
+		},
+	})
+}
+
+func TestLinter_lintSchema(t *testing.T) {
+	rules, ruleConfigs, err := NewLinter().enabledRules()
+	require.NoError(t, err)
+
+	got := lintSchema(lintTestSchema(), rules, ruleConfigs)
+
+	assert.Equal(t, []*LintFinding{
+		{Rule: "unindexed-foreign-key", Severity: "warning", Table: "articles", Column: "editor_id", Message: "foreign key (editor_id) doesn't have supporting index"},
+		{Rule: "unindexed-foreign-key", Severity: "warning", Table: "articles", Column: "category_id", Message: "foreign key (category_id) doesn't have supporting index"},
+		{Rule: "missing-referenced-table", Severity: "error", Table: "articles", Column: "category_id", Message: "foreign key refers missing table categories"},
+		{Rule: "nullable-foreign-key", Severity: "note", Table: "articles", Column: "editor_id", Message: "foreign key column editor_id is nullable"},
+		{Rule: "redundant-index", Severity: "warning", Table: "articles", Column: "user_id", Message: "index index_articles_on_user_id is redundant with index_articles_on_user_id_and_title"},
+		{Rule: "naming-convention", Severity: "note", Table: "articles", Column: "Title", Message: "column name Title doesn't match ^[a-z][a-z0-9_]*$"},
+		{Rule: "redundant-index", Severity: "warning", Table: "users", Column: "email", Message: "index index_users_on_email_2 is duplicate of index_users_on_email"},
+		{Rule: "no-primary-key", Severity: "warning", Table: "UserLogs", Message: "table doesn't have primary key"},
+		{Rule: "missing-referenced-table", Severity: "error", Table: "UserLogs", Column: "user_id", Message: "foreign key refers missing column users.uuid"},
+		{Rule: "naming-convention", Severity: "note", Table: "UserLogs", Message: "table name UserLogs doesn't match ^[a-z][a-z0-9_]*$"},
+	}, got)
+}
+
+func Test_checkUnindexedForeignKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		table *db.Table
+		want  int
+	}{
+		{
+			name: "composite foreign key is covered by leading columns of index in any order",
+			table: &db.Table{
+				Name: "order_items",
+				ForeignKeys: []*db.ForeignKey{
+					{FromColumn: "order_id", ToTable: "orders", ToColumn: "id", Name: "fk_order"},
+					{FromColumn: "shop_id", ToTable: "orders", ToColumn: "shop_id", Name: "fk_order"},
+				},
+				Indexes: []*db.Index{
+					{Name: "index_order_items", Columns: []string{"shop_id", "order_id", "item_id"}},
+				},
+			},
+			want: 0,
+		},
+		{
+			name: "foreign key isn't leading column of index",
+			table: &db.Table{
+				Name: "order_items",
+				ForeignKeys: []*db.ForeignKey{
+					{FromColumn: "order_id", ToTable: "orders", ToColumn: "id"},
+				},
+				Indexes: []*db.Index{
+					{Name: "index_order_items", Columns: []string{"item_id", "order_id"}},
+				},
+			},
+			want: 1,
+		},
+		{
+			name: "foreign key is covered by primary key",
+			table: &db.Table{
+				Name: "order_items",
+				Columns: []*db.Column{
+					{Name: "order_id", Type: "integer", PrimaryKey: true},
+					{Name: "item_id", Type: "integer", PrimaryKey: true},
+				},
+				ForeignKeys: []*db.ForeignKey{
+					{FromColumn: "order_id", ToTable: "orders", ToColumn: "id"},
+				},
+			},
+			want: 0,
+		},
+		{
+			name: "expression index doesn't support foreign key",
+			table: &db.Table{
+				Name: "order_items",
+				ForeignKeys: []*db.ForeignKey{
+					{FromColumn: "order_id", ToTable: "orders", ToColumn: "id"},
+				},
+				Indexes: []*db.Index{
+					{Name: "index_order_items", Columns: []string{"order_id"}, KeyParts: []*db.IndexKeyPart{{Expression: "lower(name)"}}},
+				},
+			},
+			want: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := checkUnindexedForeignKey(nil, db.NewTableResolver([]*db.Table{tt.table}), tt.table)
+			assert.Len(t, got, tt.want)
+		})
+	}
+}
+
+func Test_checkRedundantIndex(t *testing.T) {
+	tests := []struct {
+		name    string
+		indexes []*db.Index
+		want    int
+	}{
+		{
+			name: "duplicate index",
+			indexes: []*db.Index{
+				{Name: "index_1", Columns: []string{"a", "b"}},
+				{Name: "index_2", Columns: []string{"A", "B"}},
+			},
+			want: 1,
+		},
+		{
+			name: "different sort order",
+			indexes: []*db.Index{
+				{Name: "index_1", Columns: []string{"a", "b"}, Orders: []string{"DESC"}},
+				{Name: "index_2", Columns: []string{"a", "b"}},
+			},
+			want: 0,
+		},
+		{
+			name: "different prefix length",
+			indexes: []*db.Index{
+				{Name: "index_1", Columns: []string{"name"}, Lengths: []int{10}},
+				{Name: "index_2", Columns: []string{"name"}},
+			},
+			want: 0,
+		},
+		{
+			name: "covered by leading columns with the same sort order",
+			indexes: []*db.Index{
+				{Name: "index_1", Columns: []string{"a"}, Orders: []string{"DESC"}},
+				{Name: "index_2", Columns: []string{"a", "b"}, Orders: []string{"DESC"}},
+			},
+			want: 1,
+		},
+		{
+			name: "leading column has different prefix length",
+			indexes: []*db.Index{
+				{Name: "index_1", Columns: []string{"name"}},
+				{Name: "index_2", Columns: []string{"name", "email"}, Lengths: []int{10}},
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table := &db.Table{Name: "users", Indexes: tt.indexes}
+			got := checkRedundantIndex(nil, db.NewTableResolver([]*db.Table{table}), table)
+			assert.Len(t, got, tt.want)
+		})
+	}
+}
+
+func TestLoadLintConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown rule",
+			content: `{"rules": {"unknown": {"enabled": false}}}`,
+			wantErr: "unknown is unknown lint rule",
+		},
+		{
+			name:    "unknown severity",
+			content: `{"rules": {"no-primary-key": {"severity": "fatal"}}}`,
+			wantErr: "fatal is unknown severity of lint rule no-primary-key",
+		},
+		{
+			name:    "empty rule config",
+			content: `{"rules": {"no-primary-key": null}}`,
+			wantErr: "config of lint rule no-primary-key is empty",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadLintConfig(writeTempFile(t, "lint.json", tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLinter_Run(t *testing.T) {
+	t.Run("with config", func(t *testing.T) {
+		configPath := writeTempFile(t, "lint.json", `{
+  "rules": {
+    "unindexed-foreign-key": {"enabled": false},
+    "redundant-index": {"enabled": false},
+    "nullable-foreign-key": {"enabled": false},
+    "missing-referenced-table": {"severity": "warning"},
+    "naming-convention": {"table": "^[A-Za-z]+$", "column": "^[A-Za-z_]+$"}
+  }
+}`)
+		outputPath := filepath.Join(t.TempDir(), "lint.txt")
+
+		l := &Linter{Filepath: outputPath, Config: configPath, FailLevel: "error"}
+		failed, err := l.Run(lintTestSchema())
+		require.NoError(t, err)
+		assert.False(t, failed)
+
+		content, err := os.ReadFile(outputPath)
+		require.NoError(t, err)
+		assert.Equal(t, `articles.category_id: warning: foreign key refers missing table categories [missing-referenced-table]
+UserLogs: warning: table doesn't have primary key [no-primary-key]
+UserLogs.user_id: warning: foreign key refers missing column users.uuid [missing-referenced-table]
+
+3 problems (3 warnings)
+`, string(content))
+	})
+
+	t.Run("fails with findings", func(t *testing.T) {
+		l := &Linter{Filepath: filepath.Join(t.TempDir(), "lint.txt")}
+		failed, err := l.Run(lintTestSchema())
+		require.NoError(t, err)
+		assert.True(t, failed)
+	})
+
+	t.Run("without findings", func(t *testing.T) {
+		outputPath := filepath.Join(t.TempDir(), "lint.txt")
+		schema := db.NewSchema([]*db.Table{
+			{Name: "users", Columns: []*db.Column{{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true}}},
+		})
+
+		l := &Linter{Filepath: outputPath}
+		failed, err := l.Run(schema)
+		require.NoError(t, err)
+		assert.False(t, failed)
+
+		content, err := os.ReadFile(outputPath)
+		require.NoError(t, err)
+		assert.Empty(t, string(content))
+	})
+
+	t.Run("json", func(t *testing.T) {
+		outputPath := filepath.Join(t.TempDir(), "lint.json")
+
+		l := &Linter{Filepath: outputPath, Format: "json"}
+		_, err := l.Run(lintTestSchema())
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(outputPath)
+		require.NoError(t, err)
+
+		var findings []*LintFinding
+		require.NoError(t, json.Unmarshal(content, &findings))
+		assert.Len(t, findings, 10)
+		assert.Equal(t, &LintFinding{Rule: "no-primary-key", Severity: "warning", Table: "UserLogs", Message: "table doesn't have primary key"}, findings[7])
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		outputPath := filepath.Join(t.TempDir(), "lint.sarif")
+
+		l := &Linter{Filepath: outputPath, Format: "sarif", SourceURI: "db/schema.rb"}
+		_, err := l.Run(lintTestSchema())
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(outputPath)
+		require.NoError(t, err)
+
+		var log sarifLog
+		require.NoError(t, json.Unmarshal(content, &log))
+		assert.Equal(t, "2.1.0", log.Version)
+		if assert.Len(t, log.Runs, 1) {
+			assert.Len(t, log.Runs[0].Tool.Driver.Rules, len(lintRules))
+			assert.Len(t, log.Runs[0].Results, 10)
+			assert.Equal(t, &sarifResult{
+				RuleID:  "missing-referenced-table",
+				Level:   "error",
+				Message: &sarifMessage{Text: "articles.category_id: foreign key refers missing table categories"},
+				Locations: []*sarifLocation{
+					{
+						PhysicalLocation: &sarifPhysicalLocation{ArtifactLocation: &sarifArtifactLocation{URI: "db/schema.rb"}},
+						LogicalLocations: []*sarifLogicalLocation{{Name: "category_id", FullyQualifiedName: "articles.category_id", Kind: "column"}},
+					},
+				},
+			}, log.Runs[0].Results[2])
+		}
+	})
+
+	tests := []struct {
+		name    string
+		l       *Linter
+		wantErr string
+	}{
+		{
+			name:    "unknown format",
+			l:       &Linter{Format: "xml"},
+			wantErr: "xml is unknown lint format",
+		},
+		{
+			name:    "unknown fail level",
+			l:       &Linter{FailLevel: "fatal"},
+			wantErr: "fatal is unknown fail level",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.l.Run(lintTestSchema())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package lib
+
+import (
+	"fmt"
+	"github.com/sue445/plant_erd/db"
+	"sort"
+)
+
+// DefaultFormat represents format which is used when format isn't specified
+const DefaultFormat = "plant_uml"
+
+// Renderer represents output format of ERD
+type Renderer interface {
+	// Render returns ERD of schema
+	Render(schema *db.Schema, option *RenderOption) (string, error)
+}
+
+// RendererFunc is an adapter to use ordinary function as Renderer
+type RendererFunc func(schema *db.Schema, option *RenderOption) (string, error)
+
+// Render calls f(schema, option)
+func (f RendererFunc) Render(schema *db.Schema, option *RenderOption) (string, error) {
+	return f(schema, option)
+}
+
+// RenderOption represents option for Renderer
+type RenderOption struct {
+	ShowIndex    bool
+	ShowComment  bool
+	ShowTrigger  bool
+	EmbedMermaid bool
+
+	// Style represents diagram style (er, class)
+	Style string
+
+	// Columns represents which columns are printed (all, keys, none)
+	Columns string
+
+	// GroupStyle represents PlantUML block which wraps tables of the same group (package, namespace, rectangle)
+	GroupStyle string
+
+	// Relation represents how relations between tables are drawn
+	Relation db.RelationOption
+
+	// Decoration represents theme, skinparam, title and so on (plant_uml only)
+	Decoration db.PlantUmlDecoration
+}
+
+// diagramOption returns option for db.Schema renderers
+func (o *RenderOption) diagramOption() db.DiagramOption {
+	return db.DiagramOption{
+		ShowIndex:   o.ShowIndex,
+		ShowComment: o.ShowComment,
+		ShowTrigger: o.ShowTrigger,
+		Columns:     o.Columns,
+		GroupStyle:  o.GroupStyle,
+		Relation:    o.Relation,
+	}
+}
+
+var renderers = map[string]Renderer{
+	"plant_uml": RendererFunc(renderPlantUml),
+	"mermaid":   RendererFunc(renderMermaid),
+	"d2":        RendererFunc(renderD2),
+	"html":      RendererFunc(renderHTML),
+	"markdown":  RendererFunc(renderMarkdown),
+	"drawio":    RendererFunc(renderDrawio),
+	"svg":       RendererFunc(renderSVG),
+}
+
+var rendererExtensions = map[string]string{
+	"plant_uml": ".pu",
+	"mermaid":   ".mmd",
+	"d2":        ".d2",
+	"html":      ".html",
+	"markdown":  ".md",
+	"drawio":    ".drawio",
+	"svg":       ".svg",
+}
+
+// RendererExtension returns file extension of format. This returns .txt when format doesn't have known extension (e.g. renderer which is registered with RegisterRenderer)
+func RendererExtension(name string) string {
+	if name == "" {
+		name = DefaultFormat
+	}
+
+	if extension, ok := rendererExtensions[name]; ok {
+		return extension
+	}
+	return ".txt"
+}
+
+// RegisterRenderer registers renderer with format name. Registered renderer overrides existing renderer which has the same name
+func RegisterRenderer(name string, renderer Renderer) {
+	renderers[name] = renderer
+}
+
+// FindRenderer returns renderer which is registered with format name
+func FindRenderer(name string) (Renderer, error) {
+	if name == "" {
+		name = DefaultFormat
+	}
+
+	renderer, ok := renderers[name]
+	if !ok {
+		return nil, fmt.Errorf("%s is unknown format", name)
+	}
+
+	return renderer, nil
+}
+
+// RendererNames returns sorted names of registered renderers
+func RendererNames() []string {
+	var names []string
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func renderPlantUml(schema *db.Schema, option *RenderOption) (string, error) {
+	if option.Style == "class" {
+		return option.Decoration.Decorate(schema.ToClassDiagram(option.diagramOption())), nil
+	}
+
+	return option.Decoration.Decorate(schema.ToErd(option.diagramOption())), nil
+}
+
+func renderMermaid(schema *db.Schema, option *RenderOption) (string, error) {
+	if option.Style == "class" {
+		return schema.ToMermaidClassDiagram(option.diagramOption()), nil
+	}
+
+	return schema.ToMermaid(option.diagramOption()), nil
+}
+
+func renderD2(schema *db.Schema, option *RenderOption) (string, error) {
+	return schema.ToD2(option.ShowTrigger), nil
+}
+
+func renderHTML(schema *db.Schema, _ *RenderOption) (string, error) {
+	return generateHTML(schema)
+}
+
+func renderMarkdown(schema *db.Schema, option *RenderOption) (string, error) {
+	return schema.ToMarkdown(option.EmbedMermaid), nil
+}
+
+func renderDrawio(schema *db.Schema, option *RenderOption) (string, error) {
+	return schema.ToDrawio(option.ShowTrigger), nil
+}
+
+func renderSVG(schema *db.Schema, option *RenderOption) (string, error) {
+	return schema.ToSVG(option.ShowTrigger), nil
+}
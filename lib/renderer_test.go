@@ -0,0 +1,90 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sue445/plant_erd/db"
+)
+
+func TestFindRenderer(t *testing.T) {
+	schema := db.NewSchema([]*db.Table{
+		{
+			Name:    "users",
+			Columns: []*db.Column{{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true}},
+		},
+	})
+
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{
+			name:   "default",
+			format: "",
+			want:   "entity users {\n  * id : integer\n}",
+		},
+		{
+			name:   "plant_uml",
+			format: "plant_uml",
+			want:   "entity users {\n  * id : integer\n}",
+		},
+		{
+			name:   "mermaid",
+			format: "mermaid",
+			want:   "erDiagram\n\nusers {\n  integer id PK \"not null\"\n}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer, err := FindRenderer(tt.format)
+			require.NoError(t, err)
+
+			got, err := renderer.Render(schema, &RenderOption{ShowIndex: true, ShowComment: true})
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := FindRenderer("unknown")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown is unknown format")
+	})
+}
+
+func TestRegisterRenderer(t *testing.T) {
+	RegisterRenderer("table_names", RendererFunc(func(schema *db.Schema, _ *RenderOption) (string, error) {
+		var names []string
+		for _, table := range schema.Tables {
+			names = append(names, table.Name)
+		}
+		return names[0], nil
+	}))
+	defer delete(renderers, "table_names")
+
+	assert.Contains(t, RendererNames(), "table_names")
+
+	g := &ErdGenerator{Format: "table_names"}
+	got, err := g.generate(db.NewSchema([]*db.Table{{Name: "users"}}))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "users", got)
+	}
+}
+
+func TestRendererNames(t *testing.T) {
+	assert.Equal(t, []string{"d2", "drawio", "html", "markdown", "mermaid", "plant_uml", "svg"}, RendererNames())
+}
+
+func TestRendererExtension(t *testing.T) {
+	assert.Equal(t, ".pu", RendererExtension(""))
+	assert.Equal(t, ".mmd", RendererExtension("mermaid"))
+	assert.Equal(t, ".txt", RendererExtension("unknown"))
+
+	for _, name := range RendererNames() {
+		assert.NotEqual(t, ".txt", RendererExtension(name), name)
+	}
+}
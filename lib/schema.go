@@ -6,8 +6,24 @@ import (
 	"github.com/sue445/plant_erd/db"
 )
 
+// PolymorphicTypeSampleLimit represents max number of values which are sampled from type column of polymorphic association
+const PolymorphicTypeSampleLimit = 100
+
+// LoadSchemaOption represents option for LoadSchema
+type LoadSchemaOption struct {
+	WithStats bool
+
+	// SamplePolymorphicTypes represents whether distinct values of type columns of polymorphic associations are sampled (c.f. db.Schema.DetectPolymorphicAssociations)
+	SamplePolymorphicTypes bool
+}
+
+// NewLoadSchemaOption returns a new LoadSchemaOption instance
+func NewLoadSchemaOption() *LoadSchemaOption {
+	return &LoadSchemaOption{}
+}
+
 // LoadSchema load schema from adapter
-func LoadSchema(adapter adapter.Adapter) (*db.Schema, error) {
+func LoadSchema(adapter adapter.Adapter, option *LoadSchemaOption) (*db.Schema, error) {
 	tableNames, err := adapter.GetAllTableNames()
 	if err != nil {
 		return nil, errors.WithStack(err)
@@ -19,8 +35,60 @@ func LoadSchema(adapter adapter.Adapter) (*db.Schema, error) {
 		if err != nil {
 			return nil, errors.WithStack(err)
 		}
+
+		if option.WithStats {
+			stats, err := loadTableStats(adapter, tableName)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+			table.Stats = stats
+		}
+
+		if option.SamplePolymorphicTypes {
+			err := samplePolymorphicTypes(adapter, tableName, table)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+
 		tables = append(tables, table)
 	}
 
 	return db.NewSchema(tables), nil
 }
+
+func loadTableStats(a adapter.Adapter, tableName string) (*db.TableStats, error) {
+	statsAdapter, ok := a.(adapter.StatsAdapter)
+	if !ok {
+		return nil, nil
+	}
+
+	stats, err := statsAdapter.GetTableStats(tableName)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return stats, nil
+}
+
+func samplePolymorphicTypes(a adapter.Adapter, tableName string, table *db.Table) error {
+	sampleAdapter, ok := a.(adapter.SampleAdapter)
+	if !ok {
+		return nil
+	}
+
+	for _, association := range table.PolymorphicAssociations() {
+		values, err := sampleAdapter.GetColumnValues(tableName, association.TypeColumn, PolymorphicTypeSampleLimit)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		for _, column := range table.Columns {
+			if column.Name == association.TypeColumn {
+				column.SampleValues = values
+			}
+		}
+	}
+
+	return nil
+}
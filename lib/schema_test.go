@@ -0,0 +1,40 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/sue445/plant_erd/adapter/sqlite3"
+)
+
+func TestLoadSchema_withSamplePolymorphicTypes(t *testing.T) {
+	withDatabase(func(a *sqlite3.Adapter) {
+		a.DB.MustExec(`
+			CREATE TABLE articles (
+				id integer not null primary key
+		);`)
+		a.DB.MustExec(`
+			CREATE TABLE comments (
+				id               integer not null primary key,
+				commentable_type text,
+				commentable_id   integer,
+				body             text
+		);`)
+		a.DB.MustExec("INSERT INTO comments (id, commentable_type, commentable_id, body) VALUES (1, 'Article', 1, 'a'), (2, 'Article', 2, 'b')")
+
+		t.Run("without SamplePolymorphicTypes", func(t *testing.T) {
+			schema, err := LoadSchema(a, &LoadSchemaOption{})
+			if assert.NoError(t, err) {
+				assert.Nil(t, schema.Tables[1].Columns[1].SampleValues)
+			}
+		})
+
+		t.Run("with SamplePolymorphicTypes", func(t *testing.T) {
+			schema, err := LoadSchema(a, &LoadSchemaOption{SamplePolymorphicTypes: true})
+			if assert.NoError(t, err) {
+				assert.Equal(t, []string{"Article"}, schema.Tables[1].Columns[1].SampleValues)
+				assert.Nil(t, schema.Tables[1].Columns[3].SampleValues)
+			}
+		})
+	})
+}
@@ -0,0 +1,119 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/cockroachdb/errors"
+	"github.com/sue445/plant_erd/db"
+	"os"
+	"regexp"
+)
+
+// TagConfig represents config file which adds stereotypes and colors to tables matched with rules
+//
+// e.g.
+//
+//	{
+//	  "tags": [
+//	    {"name": "deprecated", "table": "^legacy_", "color": "#FFCCCC"},
+//	    {"name": "billing", "schema": "billing"},
+//	    {"name": "no_pk", "noPrimaryKey": true, "color": "#FFFF99"}
+//	  ]
+//	}
+type TagConfig struct {
+	Tags []*TagRule `json:"tags"`
+}
+
+// TagRule represents a stereotype which is added to tables matched with all conditions
+type TagRule struct {
+	Name string `json:"name"`
+
+	// Color represents background color of table (e.g. #FFCCCC). Color of the first matched rule is used
+	Color string `json:"color"`
+
+	// Table represents regex pattern of table name
+	Table string `json:"table"`
+
+	// Schema represents schema name of table (e.g. public of public.users)
+	Schema string `json:"schema"`
+
+	// NoPrimaryKey represents whether table doesn't have primary key
+	NoPrimaryKey bool `json:"noPrimaryKey"`
+
+	tableRe *regexp.Regexp
+}
+
+// LoadTagConfig returns TagConfig which is read from JSON file
+func LoadTagConfig(configPath string) (*TagConfig, error) {
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var config TagConfig
+	err = json.Unmarshal(content, &config)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	for _, rule := range config.Tags {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("name of tag is required")
+		}
+
+		if rule.Table == "" && rule.Schema == "" && !rule.NoPrimaryKey {
+			return nil, fmt.Errorf("tag %s doesn't have any conditions (table, schema, noPrimaryKey)", rule.Name)
+		}
+
+		if rule.Table != "" {
+			rule.tableRe, err = regexp.Compile(rule.Table)
+			if err != nil {
+				return nil, errors.WithStack(err)
+			}
+		}
+	}
+
+	return &config, nil
+}
+
+// match returns whether table matches with all conditions of rule
+func (r *TagRule) match(table *db.Table) bool {
+	if r.tableRe != nil && !r.tableRe.MatchString(table.Name) {
+		return false
+	}
+
+	if r.Schema != "" && schemaGroupName(table.Name) != r.Schema {
+		return false
+	}
+
+	if r.NoPrimaryKey && len(table.GetPrimaryKeyColumns()) > 0 {
+		return false
+	}
+
+	return true
+}
+
+// tagTables returns tables which Tags and Color are set with rules. Existing tags (e.g. db.PolymorphicTag) are kept
+func (c *TagConfig) tagTables(tables []*db.Table) []*db.Table {
+	var tagged []*db.Table
+	for _, table := range tables {
+		copied := *table
+		copied.Tags = append([]string(nil), table.Tags...)
+		copied.Color = ""
+
+		for _, rule := range c.Tags {
+			if !rule.match(table) {
+				continue
+			}
+
+			copied.Tags = append(copied.Tags, rule.Name)
+			if copied.Color == "" {
+				copied.Color = rule.Color
+			}
+		}
+
+		tagged = append(tagged, &copied)
+	}
+
+	return tagged
+}
@@ -0,0 +1,121 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sue445/plant_erd/db"
+)
+
+func TestTagConfig_tagTables(t *testing.T) {
+	configPath := writeTempFile(t, "tags.json", `{
+  "tags": [
+    {"name": "deprecated", "table": "legacy_", "color": "#FFCCCC"},
+    {"name": "billing", "schema": "billing", "color": "#CCE5FF"},
+    {"name": "no_pk", "noPrimaryKey": true, "color": "#FFFF99"}
+  ]
+}`)
+
+	config, err := LoadTagConfig(configPath)
+	require.NoError(t, err)
+
+	idColumn := &db.Column{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true}
+	tables := []*db.Table{
+		{Name: "public.users", Columns: []*db.Column{idColumn}},
+		{Name: "public.legacy_users"},
+		{Name: "billing.invoices", Columns: []*db.Column{idColumn}},
+		{Name: "commentable", Columns: []*db.Column{idColumn}, Tags: []string{db.PolymorphicTag}},
+	}
+
+	got := config.tagTables(tables)
+
+	if assert.Len(t, got, 4) {
+		assert.Nil(t, got[0].Tags)
+		assert.Equal(t, "", got[0].Color)
+
+		assert.Equal(t, []string{"deprecated", "no_pk"}, got[1].Tags)
+		assert.Equal(t, "#FFCCCC", got[1].Color)
+
+		assert.Equal(t, []string{"billing"}, got[2].Tags)
+		assert.Equal(t, "#CCE5FF", got[2].Color)
+
+		assert.Equal(t, []string{db.PolymorphicTag}, got[3].Tags)
+	}
+
+	// original tables aren't modified
+	assert.Nil(t, tables[1].Tags)
+}
+
+func TestLoadTagConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "without name",
+			content: `{"tags": [{"table": "legacy_"}]}`,
+			wantErr: "name of tag is required",
+		},
+		{
+			name:    "without conditions",
+			content: `{"tags": [{"name": "deprecated", "color": "#FFCCCC"}]}`,
+			wantErr: "tag deprecated doesn't have any conditions (table, schema, noPrimaryKey)",
+		},
+		{
+			name:    "invalid regex",
+			content: `{"tags": [{"name": "deprecated", "table": "("}]}`,
+			wantErr: "missing closing )",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadTagConfig(writeTempFile(t, "tags.json", tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestErdGenerator_generate_withDecoration(t *testing.T) {
+	schema := db.NewSchema([]*db.Table{
+		{Name: "legacy_users"},
+	})
+
+	g := &ErdGenerator{
+		TagConfig: writeTempFile(t, "tags.json", `{"tags": [{"name": "deprecated", "table": "^legacy_", "color": "#FFCCCC"}]}`),
+		Decoration: db.PlantUmlDecoration{
+			Theme: "cerulean",
+			Title: "Example ERD",
+		},
+	}
+
+	got, err := g.generate(schema)
+	if assert.NoError(t, err) {
+		assert.Equal(t, "!theme cerulean\n\ntitle Example ERD\n\nentity legacy_users <<deprecated>> #FFCCCC {\n}", got)
+	}
+}
+
+func TestErdGenerator_generate_withTagConfigAndSkipColumns(t *testing.T) {
+	schema := db.NewSchema([]*db.Table{
+		{
+			Name: "users",
+			Columns: []*db.Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+				{Name: "name", Type: "text"},
+			},
+		},
+	})
+
+	g := &ErdGenerator{
+		TagConfig:   writeTempFile(t, "tags.json", `{"tags": [{"name": "no_pk", "noPrimaryKey": true}]}`),
+		SkipColumns: []string{"^id$"},
+	}
+
+	got, err := g.generate(schema)
+	if assert.NoError(t, err) {
+		// Primary key which is skipped is also used for tag rules
+		assert.Equal(t, "entity users {\n  name : text\n}", got)
+	}
+}
@@ -0,0 +1,111 @@
+package lib
+
+import (
+	"bytes"
+	"github.com/cockroachdb/errors"
+	"github.com/sue445/plant_erd/db"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// TemplateRenderer represents renderer which executes user-defined text/template against schema
+type TemplateRenderer struct {
+	template *template.Template
+}
+
+// TemplateReference represents foreign key which refers a table
+type TemplateReference struct {
+	// Table represents table which has foreign key
+	Table      *db.Table
+	ForeignKey *db.ForeignKey
+}
+
+// NewTemplateRenderer returns a new TemplateRenderer instance which executes template file
+func NewTemplateRenderer(templatePath string) (*TemplateRenderer, error) {
+	content, err := os.ReadFile(templatePath)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	// Functions which refer schema are replaced in Render
+	tmpl, err := template.New(filepath.Base(templatePath)).Funcs(templateFuncs(db.NewSchema(nil))).Parse(string(content))
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return &TemplateRenderer{template: tmpl}, nil
+}
+
+// Render executes template. Dot of template is *db.Schema
+func (r *TemplateRenderer) Render(schema *db.Schema, _ *RenderOption) (string, error) {
+	tmpl, err := r.template.Clone()
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	var buf bytes.Buffer
+	err = tmpl.Funcs(templateFuncs(schema)).Execute(&buf, schema)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return buf.String(), nil
+}
+
+// templateFuncs returns helper functions for template
+func templateFuncs(schema *db.Schema) template.FuncMap {
+	// table and referencedBy look up tables in the same way as relations of ERD
+	resolver := db.NewTableResolver(schema.Tables)
+
+	foreignKey := func(table *db.Table, column *db.Column) *db.ForeignKey {
+		for _, foreignKey := range table.ForeignKeys {
+			if foreignKey.FromColumn == column.Name {
+				return foreignKey
+			}
+		}
+		return nil
+	}
+
+	return template.FuncMap{
+		// table returns table of name. This returns nil when table isn't found
+		"table": resolver.Find,
+
+		// primaryKeys returns primary key columns of table
+		"primaryKeys": func(table *db.Table) []*db.Column {
+			return table.GetPrimaryKeyColumns()
+		},
+
+		// isPrimaryKey returns whether column is primary key
+		"isPrimaryKey": func(column *db.Column) bool {
+			return column.PrimaryKey
+		},
+
+		// foreignKey returns foreign key of column. This returns nil when column isn't foreign key
+		"foreignKey": foreignKey,
+
+		// isForeignKey returns whether column is foreign key
+		"isForeignKey": func(table *db.Table, column *db.Column) bool {
+			return foreignKey(table, column) != nil
+		},
+
+		// referencedBy returns foreign keys which refer table
+		"referencedBy": func(table *db.Table) []*TemplateReference {
+			var references []*TemplateReference
+			for _, other := range schema.Tables {
+				for _, foreignKey := range other.ForeignKeys {
+					if target := resolver.Find(foreignKey.ToTable); target != nil && target.Name == table.Name {
+						references = append(references, &TemplateReference{Table: other, ForeignKey: foreignKey})
+					}
+				}
+			}
+			return references
+		},
+
+		"join":    strings.Join,
+		"lower":   strings.ToLower,
+		"upper":   strings.ToUpper,
+		"replace": strings.ReplaceAll,
+	}
+}
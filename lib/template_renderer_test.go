@@ -0,0 +1,121 @@
+package lib
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/sue445/plant_erd/db"
+)
+
+func TestTemplateRenderer_Render(t *testing.T) {
+	schema := db.NewSchema([]*db.Table{
+		{
+			Name: "articles",
+			Columns: []*db.Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+				{Name: "user_id", Type: "integer", NotNull: true},
+			},
+			ForeignKeys: []*db.ForeignKey{
+				{FromColumn: "user_id", ToTable: "users", ToColumn: "id"},
+			},
+		},
+		{
+			Name: "users",
+			Columns: []*db.Column{
+				{Name: "id", Type: "integer", NotNull: true, PrimaryKey: true},
+				{Name: "name", Type: "text"},
+			},
+		},
+	})
+
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{
+			name: "columns with keys",
+			template: `{{- range .Tables }}
+{{ upper .Name }}
+{{- $table := . }}
+{{- range .Columns }}
+- {{ .Name }}{{ if isPrimaryKey . }} (PK){{ end }}{{ with foreignKey $table . }} (FK: {{ .ToTable }}.{{ .ToColumn }}){{ end }}
+{{- end }}
+{{- end }}
+`,
+			want: `
+ARTICLES
+- id (PK)
+- user_id (FK: users.id)
+USERS
+- id (PK)
+- name
+`,
+		},
+		{
+			name: "lookup",
+			template: `{{- with table "users" -}}
+pk={{ range primaryKeys . }}{{ .Name }}{{ end }}
+{{- range referencedBy . }}
+{{ .Table.Name }}.{{ .ForeignKey.FromColumn }}
+{{- end }}
+{{- end }}
+{{ if table "unknown" }}found{{ else }}not found{{ end }}
+{{ isForeignKey (table "articles") (index (table "articles").Columns 1) }}`,
+			want: `pk=id
+articles.user_id
+not found
+true`,
+		},
+		{
+			name:     "case insensitive lookup",
+			template: `{{- with table "USERS" }}{{ .Name }}{{ range referencedBy . }} <- {{ .Table.Name }}{{ end }}{{ end }}`,
+			want:     `users <- articles`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer, err := NewTemplateRenderer(writeTempFile(t, "erd.tmpl", tt.template))
+			require.NoError(t, err)
+
+			got, err := renderer.Render(schema, &RenderOption{})
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewTemplateRenderer_Error(t *testing.T) {
+	t.Run("file not found", func(t *testing.T) {
+		_, err := NewTemplateRenderer(filepath.Join(t.TempDir(), "not_found.tmpl"))
+		require.Error(t, err)
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		_, err := NewTemplateRenderer(writeTempFile(t, "erd.tmpl", "{{ range .Tables }}"))
+		require.Error(t, err)
+	})
+}
+
+func TestErdGenerator_generate_withTemplate(t *testing.T) {
+	schema := db.NewSchema([]*db.Table{{Name: "users"}})
+	templatePath := writeTempFile(t, "erd.tmpl", "{{ range .Tables }}{{ .Name }}{{ end }}")
+
+	t.Run("template", func(t *testing.T) {
+		g := &ErdGenerator{Template: templatePath}
+		got, err := g.generate(schema)
+		if assert.NoError(t, err) {
+			assert.Equal(t, "users", got)
+		}
+	})
+
+	t.Run("with format", func(t *testing.T) {
+		g := &ErdGenerator{Template: templatePath, Format: "mermaid"}
+		_, err := g.generate(schema)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--format and --template cannot be used together")
+	})
+}